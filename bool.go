@@ -1,5 +1,10 @@
 package valkyrie
 
+import (
+	"fmt"
+	"strings"
+)
+
 // BoolCheck : Represents a function that performs a validation check on a bool.
 type BoolCheck func(arg bool) error
 
@@ -8,9 +13,16 @@ type BoolRule struct {
 	// base : base is the name of the type from which the bool value will be inferred.
 	base string
 	// whites : the list of whitelisted values for this rule.
-	whites []interface{}
+	whites whitelist
 	// checks : the list of checks to be performed as part of this rule.
 	checks []BoolCheck
+	// truthy, falsy : the vocabulary of a string base, matched ignoring case and surrounding spaces.
+	// If nil, strings are converted using strconv.ParseBool.
+	truthy, falsy map[string]struct{}
+	// null : how the rule treats null values.
+	null nullability
+	// safe : whether panics raised by the checks are recovered.
+	safe bool
 	// err : the error to be thrown if the rule fails.
 	err error
 }
@@ -21,7 +33,14 @@ type BoolRule struct {
 // If the argument is one of the whitelisted values, no checks
 // will be performed upon it.
 func (b *BoolRule) Allow(args ...interface{}) *BoolRule {
-	b.whites = append(b.whites, args...)
+	b.whites.add(false, args...)
+	return b
+}
+
+// AllowLoose : Whitelists the provided values like Allow, but numbers match
+// numbers of any kind with the same value, e.g. int(5) matches int64(5) and float64(5).
+func (b *BoolRule) AllowLoose(args ...interface{}) *BoolRule {
+	b.whites.add(true, args...)
 	return b
 }
 
@@ -34,19 +53,70 @@ func (b *BoolRule) AddCheck(check BoolCheck) *BoolRule {
 // WithError : Adds a custom error to the rule.
 // This custom error (if not nil) will be thrown on every check violation
 // instead of the original error.
+// It is returned as it is, without the code and the key path of the violation; MsgError keeps them.
 func (b *BoolRule) WithError(err error) *BoolRule {
 	b.err = err
 	return b
 }
 
+// Nullable : Makes the rule pass null values, i.e. nil or nil pointers.
+func (b *BoolRule) Nullable() *BoolRule {
+	b.null = nullAllowed
+	return b
+}
+
+// NotNull : Makes the rule fail null values, i.e. nil or nil pointers, with a dedicated error.
+func (b *BoolRule) NotNull() *BoolRule {
+	b.null = nullDenied
+	return b
+}
+
+// Recover : Makes the rule recover panics raised by its checks and nested rules,
+// and report them as a ValidationError with the CodeInternal code and the stack trace.
+func (b *BoolRule) Recover() *BoolRule {
+	b.safe = true
+	return b
+}
+
+// Msg : Replaces the message of the most recently added check with the template.
+// The template may refer to the params of the check, such as {min}, and to the key path as {field}.
+// Example: StringBool().IsTrue().Msg("{field} must be accepted")
+func (b *BoolRule) Msg(template string) *BoolRule {
+	if check := b.lastCheck(); check != nil {
+		b.checks[len(b.checks)-1] = func(arg bool) error { return withMessage(template, check(arg)) }
+	}
+	return b
+}
+
+// MsgError : Replaces the error of the most recently added check with the provided error.
+// The code, the params and the key path of the failure are kept.
+func (b *BoolRule) MsgError(err error) *BoolRule {
+	if check := b.lastCheck(); check != nil {
+		b.checks[len(b.checks)-1] = func(arg bool) error { return withError(err, check(arg)) }
+	}
+	return b
+}
+
 // Apply : Applies the rule on a given argument.
-func (b *BoolRule) Apply(arg interface{}) error {
+// Pointers, driver.Valuer implementations and types with a registered Converter
+// are unwrapped before validation.
+func (b *BoolRule) Apply(arg interface{}) (err error) {
+	if b.safe {
+		defer recoverPanic(&err)
+	}
+	arg, isNull := normalize(arg, b.base)
 	if b.isWhitelisted(arg) {
 		return nil
 	}
-	boolVal, err := toBool(arg, b.base)
+	if isNull && b.null == nullAllowed {
+		return nil
+	}
+	if isNull && b.null == nullDenied {
+		return orErr(b.err, coded(CodeNotNull, nil, errNotNull()))
+	}
+	boolVal, err := b.toBool(arg)
 	if err != nil {
-		return orErr(b.err, errBool(b.base))
+		return orErr(b.err, coded(CodeType, Params{"type": b.base}, errBool(b.base)))
 	}
 
 	if err := b.performChecks(boolVal); err != nil {
@@ -62,15 +132,86 @@ func PureBool() *BoolRule {
 	return &BoolRule{base: boolType}
 }
 
+// StringBool : Creates a BoolRule which expects the arg to be a string.
+// which will be validated after conversion to bool using strconv.ParseBool.
+// Example: "true" -> true, "F" -> false, "1" -> true, note that "yes" will throw an error.
+func StringBool() *BoolRule {
+	return &BoolRule{base: stringType}
+}
+
+// LenientStringBool : Creates a BoolRule which expects the arg to be a string.
+// which will be validated after conversion to bool, ignoring case and surrounding spaces.
+// Truthy: "true", "t", "yes", "y", "on", "1". Falsy: "false", "f", "no", "n", "off", "0".
+// Example: "Yes" -> true, " off " -> false
+func LenientStringBool() *BoolRule {
+	return VocabularyStringBool(
+		[]string{"true", "t", "yes", "y", "on", "1"},
+		[]string{"false", "f", "no", "n", "off", "0"},
+	)
+}
+
+// VocabularyStringBool : Creates a BoolRule which expects the arg to be a string.
+// which will be validated after conversion to bool using the given vocabulary,
+// ignoring case and surrounding spaces.
+// It panics if a word is both truthy and falsy, as compared in the same way.
+// Example: VocabularyStringBool([]string{"ja"}, []string{"nein"}): "Ja" -> true
+func VocabularyStringBool(truthy []string, falsy []string) *BoolRule {
+	rule := &BoolRule{base: stringType, truthy: map[string]struct{}{}, falsy: map[string]struct{}{}}
+	for _, word := range truthy {
+		rule.truthy[strings.ToLower(strings.TrimSpace(word))] = struct{}{}
+	}
+	for _, word := range falsy {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if _, exists := rule.truthy[word]; exists {
+			panic(fmt.Sprintf("valkyrie: word '%s' is both truthy and falsy", word))
+		}
+		rule.falsy[word] = struct{}{}
+	}
+	return rule
+}
+
+// IntBool : Creates a BoolRule which expects the arg to be an int64.
+// which will be validated after conversion to bool. Only 0 and 1 are accepted.
+// Example: 1 -> true, 0 -> false, note that 2 will throw an error.
+func IntBool() *BoolRule {
+	return &BoolRule{base: intType}
+}
+
 // BoolRule PRIVATE METHODS #########################################
 
+// addCheck : adds a check whose errors are reported as a ValidationError with the code and the params.
+func (b *BoolRule) addCheck(code string, params Params, check BoolCheck) *BoolRule {
+	return b.AddCheck(func(arg bool) error { return coded(code, params, check(arg)) })
+}
+
+// lastCheck : returns the most recently added check, or nil if there is none.
+func (b *BoolRule) lastCheck() BoolCheck {
+	if len(b.checks) == 0 {
+		return nil
+	}
+	return b.checks[len(b.checks)-1]
+}
+
 func (b *BoolRule) isWhitelisted(value interface{}) bool {
-	for _, white := range b.whites {
-		if white == value {
-			return true
-		}
+	return b.whites.contains(value)
+}
+
+func (b *BoolRule) toBool(arg interface{}) (bool, error) {
+	if b.base != stringType || b.truthy == nil {
+		return toBool(arg, b.base)
+	}
+	str, ok := arg.(string)
+	if !ok {
+		return false, errEmpty
+	}
+	word := strings.ToLower(strings.TrimSpace(str))
+	if _, exists := b.truthy[word]; exists {
+		return true, nil
+	}
+	if _, exists := b.falsy[word]; exists {
+		return false, nil
 	}
-	return false
+	return false, errEmpty
 }
 
 func (b *BoolRule) performChecks(arg bool) error {
@@ -86,3 +227,25 @@ func (b *BoolRule) performChecks(arg bool) error {
 }
 
 // BoolRule UTILITY PUBLIC METHODS  #################################
+
+// IsTrue : Invalidates if arg is false. Useful for consent checkboxes.
+func (b *BoolRule) IsTrue() *BoolRule {
+	b.addCheck(CodeIsTrue, nil, func(arg bool) error {
+		if !arg {
+			return errBoolIsTrue()
+		}
+		return nil
+	})
+	return b
+}
+
+// IsFalse : Invalidates if arg is true.
+func (b *BoolRule) IsFalse() *BoolRule {
+	b.addCheck(CodeIsFalse, nil, func(arg bool) error {
+		if arg {
+			return errBoolIsFalse()
+		}
+		return nil
+	})
+	return b
+}
@@ -0,0 +1,38 @@
+package valkyrie
+
+import "testing"
+
+func TestStringBool(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  *BoolRule
+		arg   interface{}
+		valid bool
+	}{
+		{"strict true", StringBool(), "true", true},
+		{"strict yes", StringBool(), "yes", false},
+		{"lenient yes", LenientStringBool(), " Yes ", true},
+		{"lenient off", LenientStringBool(), "OFF", true},
+		{"lenient maybe", LenientStringBool(), "maybe", false},
+		{"vocabulary truthy", VocabularyStringBool([]string{"ja"}, []string{"nein"}), "Ja", true},
+		{"vocabulary falsy", VocabularyStringBool([]string{"ja"}, []string{"nein"}), " NEIN", true},
+		{"vocabulary unknown", VocabularyStringBool([]string{"ja"}, []string{"nein"}), "yes", false},
+		{"int one", IntBool(), int64(1), true},
+		{"int two", IntBool(), int64(2), false},
+	}
+
+	for _, test := range tests {
+		if got := test.rule.Apply(test.arg) == nil; got != test.valid {
+			t.Errorf("%s: Apply(%v) passed = %v, want %v", test.name, test.arg, got, test.valid)
+		}
+	}
+}
+
+func TestVocabularyStringBoolOverlap(t *testing.T) {
+	defer func() {
+		if recovered := recover(); recovered == nil {
+			t.Error("VocabularyStringBool() did not panic for a word both truthy and falsy")
+		}
+	}()
+	VocabularyStringBool([]string{"yes", "Ok"}, []string{"no", " ok "})
+}
@@ -0,0 +1,100 @@
+package valkyrie
+
+// Params : Represents the parameters of a failed check, such as the bound of a comparison.
+// They fill the placeholders of message templates.
+type Params map[string]interface{}
+
+// Stable codes of the ValidationError values reported by the rules.
+const (
+	CodeInternal = "internal"
+	CodeCustom   = "custom"
+	CodeType     = "type"
+	CodeNotNull  = "not_null"
+	CodeRequired = "required"
+	CodeBlind    = "blind"
+	CodeExcept   = "except"
+
+	CodeGTE = "gte"
+	CodeLTE = "lte"
+	CodeGT  = "gt"
+	CodeLT  = "lt"
+
+	CodeIsTrue  = "is_true"
+	CodeIsFalse = "is_false"
+
+	CodeLatitude  = "latitude"
+	CodeLongitude = "longitude"
+
+	CodeLenGTE  = "len_gte"
+	CodeLenLTE  = "len_lte"
+	CodeLenGT   = "len_gt"
+	CodeLenLT   = "len_lt"
+	CodePattern = "pattern"
+	CodeUUID    = "uuid"
+
+	CodeBase64 = "base64"
+	CodeHex    = "hex"
+	CodeJSON   = "json"
+
+	CodeCreditCard = "credit_card"
+	CodeIBAN       = "iban"
+	CodeBIC        = "bic"
+	CodeISBN10     = "isbn10"
+	CodeISBN13     = "isbn13"
+	CodeEAN13      = "ean13"
+
+	CodeCountryCode  = "country_code"
+	CodeCurrencyCode = "currency_code"
+	CodeLanguageTag  = "language_tag"
+	CodeTimeZone     = "time_zone"
+
+	CodeE164        = "e164"
+	CodePhoneNumber = "phone_number"
+	CodePostalCode  = "postal_code"
+
+	CodeSemVer          = "semver"
+	CodeSemVerCompare   = "semver_compare"
+	CodeSemVerRange     = "semver_range"
+	CodeSemVerSatisfies = "semver_satisfies"
+
+	CodeCron         = "cron"
+	CodeCronInterval = "cron_interval"
+	CodeJWT          = "jwt"
+	CodePassword     = "password"
+
+	CodeContainsAnyOf    = "contains_any_of"
+	CodeNotContainsAnyOf = "not_contains_any_of"
+	CodeNoSecrets        = "no_secrets"
+	CodeNoPII            = "no_pii"
+
+	CodeItemsGTE = "items_gte"
+	CodeItemsLTE = "items_lte"
+	CodeUnique   = "unique"
+	CodeForm     = "form"
+
+	CodeFileMaxSize   = "file_max_size"
+	CodeFileMinSize   = "file_min_size"
+	CodeFileType      = "file_type"
+	CodeFileExtension = "file_extension"
+	CodeFileName      = "file_name"
+	CodeImageMinSize  = "image_min_size"
+	CodeImageMaxSize  = "image_max_size"
+
+	CodeGeoJSON       = "geojson"
+	CodeGeoJSONType   = "geojson_type"
+	CodeWindingOrder  = "winding_order"
+	CodeBoundingBox   = "bounding_box"
+	CodeWithinPolygon = "within_polygon"
+
+	CodePasswordMinLength  = "password_min_length"
+	CodePasswordMaxLength  = "password_max_length"
+	CodePasswordUpper      = "password_upper"
+	CodePasswordLower      = "password_lower"
+	CodePasswordDigits     = "password_digits"
+	CodePasswordSymbols    = "password_symbols"
+	CodePasswordEntropy    = "password_entropy"
+	CodePasswordRepeated   = "password_repeated"
+	CodePasswordSequential = "password_sequential"
+	CodePasswordUsername   = "password_username"
+	CodePasswordBlocked    = "password_blocked"
+)
@@ -0,0 +1,152 @@
+// Package config loads configuration from environment variables and validates it with valkyrie rules,
+// so that all the missing and invalid variables are reported together at startup.
+package config
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/shivanshkc/valkyrie/v2"
+)
+
+// Options : Represents where and how the variables are looked up.
+type Options struct {
+	// Prefix : prepended to the keys of the rule to form the variable names, such as "APP_".
+	Prefix string
+	// Lookup : looks up a variable by name. It defaults to os.LookupEnv.
+	Lookup func(name string) (string, bool)
+	// Defaults : the values of the variables that are not set, keyed without the prefix.
+	Defaults map[string]string
+	// EmptyAsUnset : whether variables set to an empty string are treated as not set.
+	EmptyAsUnset bool
+}
+
+// Values : The loaded variables, keyed without the prefix. Variables that are not set and
+// have no default are absent.
+type Values map[string]string
+
+// Error : Reports all the missing and invalid variables at once.
+type Error struct {
+	// Errs : the failures, with the variable names as the paths of their ValidationErrors.
+	Errs valkyrie.MultiError
+}
+
+var errVariableMissing = errors.New("variable should be set")
+
+// Load : Looks up the keys of the rule as variables and validates them with the rule.
+// The values are strings, so coercing rules such as StringInt and StringBool should be used.
+// All the failures are returned together as an *Error, without changing the rule, as with MapRule.ApplyAll.
+// Example:
+//
+//	values, err := config.Load(valkyrie.PureMap().
+//		Key("PORT", true, valkyrie.StringInt().GTE(1).LTE(65535)).
+//		Key("DEBUG", false, valkyrie.StringBool()), config.Options{Prefix: "APP_"})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	port := values.Int("PORT")
+func Load(rule *valkyrie.MapRule, options Options) (Values, error) {
+	if rule == nil {
+		panic("valkyrie: nil rule for config")
+	}
+	if options.Lookup == nil {
+		options.Lookup = os.LookupEnv
+	}
+
+	values := Values{}
+	for _, key := range rule.Keys() {
+		value, exists := options.Lookup(options.Prefix + key)
+		if exists && value == "" && options.EmptyAsUnset {
+			exists = false
+		}
+		if !exists {
+			value, exists = options.Defaults[key]
+		}
+		if exists {
+			values[key] = value
+		}
+	}
+
+	arg := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		arg[key] = value
+	}
+	if err := rule.ApplyAll(arg); err != nil {
+		return nil, newError(err, options.Prefix)
+	}
+	return values, nil
+}
+
+// Error : Lists the failures with their variable names.
+func (e *Error) Error() string {
+	messages := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		messages[i] = err.Error()
+		if validationErr, ok := err.(*valkyrie.ValidationError); ok && len(validationErr.Path) > 0 {
+			messages[i] = validationErr.Field() + ": " + messages[i]
+		}
+	}
+	return "invalid configuration: " + strings.Join(messages, "; ")
+}
+
+// Unwrap : Returns the failures, so they can be inspected with errors.Is and errors.As.
+// The renderers of valkyrie, such as RenderTextTree, should be given the Errs field.
+func (e *Error) Unwrap() error {
+	return e.Errs
+}
+
+// String : Returns the value of the variable, or "" if it is absent.
+func (v Values) String(key string) string {
+	return v[key]
+}
+
+// Int : Returns the value of the variable as an int64, or 0 if it is absent or not an integer.
+func (v Values) Int(key string) int64 {
+	value, _ := strconv.ParseInt(v[key], 10, 64)
+	return value
+}
+
+// Float : Returns the value of the variable as a float64, or 0 if it is absent or not a number.
+func (v Values) Float(key string) float64 {
+	value, _ := strconv.ParseFloat(v[key], 64)
+	return value
+}
+
+// Bool : Returns the value of the variable as a bool, or false if it is absent or not a bool.
+// It accepts the values of LenientStringBool, which include those of StringBool, so it reads the variables
+// validated by either of them. Variables validated by VocabularyStringBool should be read with String.
+func (v Values) Bool(key string) bool {
+	switch strings.ToLower(strings.TrimSpace(v[key])) {
+	case "true", "t", "yes", "y", "on", "1":
+		return true
+	}
+	return false
+}
+
+// newError : creates an *Error from the failures of the rule, prefixing the key paths
+// so they hold the variable names, and reporting missing required keys as variables that should be set.
+func newError(err error, prefix string) *Error {
+	errs, ok := err.(valkyrie.MultiError)
+	if !ok {
+		errs = valkyrie.MultiError{err}
+	}
+
+	prefixed := make(valkyrie.MultiError, len(errs))
+	for i, err := range errs {
+		validationErr, ok := err.(*valkyrie.ValidationError)
+		if !ok || len(validationErr.Path) == 0 {
+			prefixed[i] = err
+			continue
+		}
+		copied := *validationErr
+		copied.Path = append([]string{prefix + copied.Path[0]}, copied.Path[1:]...)
+		if copied.Code == valkyrie.CodeRequired && len(copied.Path) == 1 {
+			copied.Params = valkyrie.Params{"key": copied.Path[0]}
+			copied.Err = errVariableMissing
+		}
+		prefixed[i] = &copied
+	}
+	return &Error{Errs: prefixed}
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shivanshkc/valkyrie/v2"
+)
+
+func TestLoad(t *testing.T) {
+	rule := valkyrie.PureMap().
+		Key("PORT", true, valkyrie.StringInt().GTE(1).LTE(65535)).
+		Key("DEBUG", false, valkyrie.StringBool()).
+		Key("NAME", false, valkyrie.PureString().LenGTE(1))
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		options Options
+		want    Values
+		// codes : the paths and the codes of the failures, or nil if the variables pass.
+		codes [][2]string
+	}{
+		{
+			name:    "prefixed variables",
+			env:     map[string]string{"APP_PORT": "8080", "APP_DEBUG": "true", "PORT": "1"},
+			options: Options{Prefix: "APP_"},
+			want:    Values{"PORT": "8080", "DEBUG": "true"},
+		},
+		{
+			name:    "defaults",
+			env:     map[string]string{"PORT": "8080"},
+			options: Options{Defaults: map[string]string{"PORT": "1", "NAME": "api"}},
+			want:    Values{"PORT": "8080", "NAME": "api"},
+		},
+		{
+			name:    "empty as unset",
+			env:     map[string]string{"PORT": "8080", "NAME": ""},
+			options: Options{EmptyAsUnset: true, Defaults: map[string]string{"NAME": "api"}},
+			want:    Values{"PORT": "8080", "NAME": "api"},
+		},
+		{
+			name:    "empty as set",
+			env:     map[string]string{"PORT": "8080", "NAME": ""},
+			options: Options{Defaults: map[string]string{"NAME": "api"}},
+			codes:   [][2]string{{"NAME", valkyrie.CodeLenGTE}},
+		},
+		{
+			name:    "all failures with prefixed paths",
+			env:     map[string]string{"APP_DEBUG": "maybe"},
+			options: Options{Prefix: "APP_"},
+			codes:   [][2]string{{"APP_PORT", valkyrie.CodeRequired}, {"APP_DEBUG", valkyrie.CodeType}},
+		},
+		{
+			name:    "invalid default",
+			env:     map[string]string{},
+			options: Options{Defaults: map[string]string{"PORT": "0"}},
+			codes:   [][2]string{{"PORT", valkyrie.CodeGTE}},
+		},
+	}
+
+	for _, test := range tests {
+		test.options.Lookup = lookupMap(test.env)
+		values, err := Load(rule, test.options)
+		if test.codes == nil {
+			if err != nil || !reflect.DeepEqual(values, test.want) {
+				t.Errorf("%s: Load() = %v, %v, want %v", test.name, values, err, test.want)
+			}
+			continue
+		}
+
+		var configErr *Error
+		if !errors.As(err, &configErr) {
+			t.Errorf("%s: Load() error = %#v, want an *Error", test.name, err)
+			continue
+		}
+		var codes [][2]string
+		for _, err := range configErr.Errs {
+			var validationErr *valkyrie.ValidationError
+			if errors.As(err, &validationErr) {
+				codes = append(codes, [2]string{validationErr.Field(), validationErr.Code})
+			}
+		}
+		if !reflect.DeepEqual(codes, test.codes) {
+			t.Errorf("%s: Load() failures = %v, want %v", test.name, codes, test.codes)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	rule := valkyrie.PureMap().Key("PORT", true, valkyrie.StringInt())
+	_, err := Load(rule, Options{Prefix: "APP_", Lookup: lookupMap(nil)})
+
+	if want := "invalid configuration: APP_PORT: variable should be set"; err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %q", err, want)
+	}
+	var validationErr *valkyrie.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Params["key"] != "APP_PORT" {
+		t.Errorf("Load() error = %#v, want the key param to be the variable name", err)
+	}
+}
+
+func TestLoadNilRule(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Load() did not panic for a nil rule")
+		}
+	}()
+	_, _ = Load(nil, Options{})
+}
+
+func TestValues(t *testing.T) {
+	values := Values{"PORT": "8080", "RATIO": "0.5", "DEBUG": " Yes ", "NAME": "api", "BAD": "x"}
+
+	if got := values.String("NAME"); got != "api" {
+		t.Errorf("String() = %q, want %q", got, "api")
+	}
+	if got := values.Int("PORT"); got != 8080 {
+		t.Errorf("Int() = %d, want 8080", got)
+	}
+	if got := values.Int("BAD"); got != 0 {
+		t.Errorf("Int() of an invalid value = %d, want 0", got)
+	}
+	if got := values.Float("RATIO"); got != 0.5 {
+		t.Errorf("Float() = %v, want 0.5", got)
+	}
+	if got := values.Bool("DEBUG"); !got {
+		t.Errorf("Bool() = %v, want true", got)
+	}
+	if got := values.Bool("MISSING"); got {
+		t.Errorf("Bool() of an absent value = %v, want false", got)
+	}
+}
+
+// lookupMap : returns a Lookup func reading the variables from the given map.
+func lookupMap(env map[string]string) func(name string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
@@ -5,6 +5,4 @@ const (
 	intType    string = "int64"
 	floatType  string = "float64"
 	stringType string = "string"
-
-	uuidRegex = "^[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-4[a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12}$"
 )
@@ -0,0 +1,122 @@
+package valkyrie
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Converter : Represents a function that converts a value of a domain type into a value the rules
+// understand: bool, int64, float64, string, map[string]interface{} or nil for null.
+type Converter func(arg interface{}) (interface{}, error)
+
+// maxUnwrapDepth : the maximum count of unwrapping steps, which guards against converters
+// returning values of their own type.
+const maxUnwrapDepth = 16
+
+var (
+	// converters : the registered converters, keyed by the type they convert.
+	converters = map[reflect.Type]Converter{}
+	// convertersMutex : guards the converters.
+	convertersMutex sync.RWMutex
+)
+
+// RegisterConverter : Registers a converter for the type of the given sample value, so that values
+// of that type are accepted by all rules. A converter registered for a type replaces the previous one.
+// Example:
+//
+//	RegisterConverter(UserID(""), func(arg interface{}) (interface{}, error) {
+//		return string(arg.(UserID)), nil
+//	})
+func RegisterConverter(sample interface{}, converter Converter) {
+	convertersMutex.Lock()
+	defer convertersMutex.Unlock()
+	converters[reflect.TypeOf(sample)] = converter
+}
+
+func lookupConverter(t reflect.Type) (Converter, bool) {
+	convertersMutex.RLock()
+	defer convertersMutex.RUnlock()
+	converter, exists := converters[t]
+	return converter, exists
+}
+
+// convertJSONNumber : converts a json.Number, as decoded with json.Decoder.UseNumber, into the type
+// of a numeric base. Numbers that do not fit, and numbers for other bases such as strings,
+// are returned as they are, so the conversion fails.
+func convertJSONNumber(number json.Number, base string) interface{} {
+	switch base {
+	case intType:
+		if intVal, err := number.Int64(); err == nil {
+			return intVal
+		}
+	case floatType:
+		if floatVal, err := number.Float64(); err == nil {
+			return floatVal
+		}
+	}
+	return number
+}
+
+// normalize : unwraps the argument into a value the conversions understand. It applies the registered
+// converters, calls driver.Valuer implementations (such as sql.NullString, whose invalid values are null)
+// and dereferences pointers of any depth. A json.Number is converted into the type of a numeric base.
+// For a string base, it also converts []byte, encoding.TextMarshaler and fmt.Stringer values into strings.
+// It also reports whether the value is null.
+func normalize(arg interface{}, base string) (interface{}, bool) {
+	for depth := 0; depth < maxUnwrapDepth; depth++ {
+		switch arg.(type) {
+		case nil:
+			return nil, true
+		case bool, int64, float64, string, map[string]interface{}:
+			return arg, false
+		}
+
+		if number, ok := arg.(json.Number); ok {
+			return convertJSONNumber(number, base), false
+		}
+
+		value := reflect.ValueOf(arg)
+		if value.Kind() == reflect.Ptr && value.IsNil() {
+			return nil, true
+		}
+		if converter, exists := lookupConverter(value.Type()); exists {
+			converted, err := converter(arg)
+			if err != nil {
+				return arg, false
+			}
+			arg = converted
+			continue
+		}
+		if valuer, ok := arg.(driver.Valuer); ok {
+			converted, err := valuer.Value()
+			if err != nil {
+				return arg, false
+			}
+			arg = converted
+			continue
+		}
+		if value.Kind() == reflect.Ptr {
+			arg = value.Elem().Interface()
+			continue
+		}
+
+		if base == stringType {
+			switch typed := arg.(type) {
+			case []byte:
+				return string(typed), false
+			case encoding.TextMarshaler:
+				if text, err := typed.MarshalText(); err == nil {
+					return string(text), false
+				}
+			case fmt.Stringer:
+				return typed.String(), false
+			}
+		}
+		return arg, false
+	}
+	return arg, false
+}
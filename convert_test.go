@@ -0,0 +1,62 @@
+package valkyrie
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// testUserID : a domain type converted into a string by a registered converter.
+type testUserID struct{ id string }
+
+// testCents : a domain type whose converter fails for negative amounts.
+type testCents int
+
+func TestConversions(t *testing.T) {
+	RegisterConverter(testUserID{}, func(arg interface{}) (interface{}, error) {
+		return arg.(testUserID).id, nil
+	})
+	RegisterConverter(testCents(0), func(arg interface{}) (interface{}, error) {
+		if arg.(testCents) < 0 {
+			return nil, errors.New("negative amount")
+		}
+		return int64(arg.(testCents)), nil
+	})
+
+	tests := []struct {
+		name string
+		rule Rule
+		arg  interface{}
+		want bool
+	}{
+		{"valid null string", PureString().LenGTE(2), sql.NullString{String: "ab", Valid: true}, true},
+		{"invalid null string", PureString().Nullable(), sql.NullString{String: "ab"}, true},
+		{"invalid null string not nullable", PureString(), sql.NullString{String: "ab"}, false},
+		{"valid null int", PureInt().GTE(3), sql.NullInt64{Int64: 3, Valid: true}, true},
+		{"valid null float", PureFloat().LTE(1), sql.NullFloat64{Float64: 0.5, Valid: true}, true},
+		{"valid null bool", PureBool(), sql.NullBool{Bool: true, Valid: true}, true},
+		{"pointer to a null string", PureString().Nullable(), &sql.NullString{}, true},
+		{"bytes", PureString().LenLTE(3), []byte("abc"), true},
+		{"bytes for an int", PureInt(), []byte("1"), false},
+		{"text marshaler", PureString().Allow("10.0.0.1").Blind(), net.ParseIP("10.0.0.1"), true},
+		{"stringer", PureString().Allow("1m0s").Blind(), time.Minute, true},
+		{"json number for an int", PureInt().GTE(7), json.Number("7"), true},
+		{"json number with a fraction for an int", PureInt(), json.Number("7.5"), false},
+		{"json number for a float", PureFloat().GTE(7), json.Number("7.5"), true},
+		{"json number for a string", PureString(), json.Number("7"), false},
+		{"registered converter", PureString().LenGTE(3), testUserID{"u-1"}, true},
+		{"registered converter failing the check", PureString().LenGTE(4), testUserID{"u-1"}, false},
+		{"registered converter of a pointer", PureString(), &testUserID{"u-1"}, true},
+		{"registered converter error", PureInt(), testCents(-1), false},
+		{"registered int converter", PureInt().LTE(100), testCents(99), true},
+	}
+
+	for _, test := range tests {
+		if got := test.rule.Apply(test.arg) == nil; got != test.want {
+			t.Errorf("%s: Apply(%#v) passed = %v, want %v", test.name, test.arg, got, test.want)
+		}
+	}
+}
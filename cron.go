@@ -0,0 +1,296 @@
+package valkyrie
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronFlavor : Represents the cron expression syntaxes accepted by a Cron check.
+// Flavors can be combined using '|'.
+type CronFlavor int
+
+const (
+	// CronStandard : The 5 field syntax: minute, hour, day of month, month and day of week.
+	// Example: "*/15 9-17 * * MON-FRI"
+	CronStandard CronFlavor = 1 << iota
+	// CronSeconds : The 6 field syntax with a leading seconds field.
+	// Example: "0 */15 9-17 * * MON-FRI"
+	CronSeconds
+	// CronDescriptors : The predefined schedules @yearly, @annually, @monthly, @weekly, @daily,
+	// @midnight, @hourly and @reboot, along with "@every <duration>".
+	// Example: "@daily", "@every 1h30m"
+	CronDescriptors
+)
+
+// cronField : the name and the inclusive range of the values of a cron field.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	cronSecond  = cronField{name: "second", min: 0, max: 59}
+	cronMinute  = cronField{name: "minute", min: 0, max: 59}
+	cronHour    = cronField{name: "hour", min: 0, max: 23}
+	cronDom     = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth   = cronField{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	cronWeekday = cronField{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+)
+
+// cronDescriptors : the predefined schedules and their 5 field equivalents.
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronSchedule : a parsed cron expression. The fields are bit sets of the matching values.
+type cronSchedule struct {
+	seconds, minutes, hours, doms, months, weekdays uint64
+	// domStar, weekdayStar : whether the day fields are unrestricted, which decides whether the
+	// day of month and the day of week are combined with AND (either unrestricted) or OR (both restricted).
+	domStar, weekdayStar bool
+	// dayGap : the smallest count of days between two consecutive firing days.
+	dayGap int
+	// every : the fixed interval of "@every" schedules.
+	every time.Duration
+	// reboot : whether this is the "@reboot" schedule, which fires only once.
+	reboot bool
+}
+
+// parseCron : parses a cron expression of the given flavors.
+func parseCron(str string, flavor CronFlavor) (*cronSchedule, error) {
+	str = strings.TrimSpace(str)
+	if strings.HasPrefix(str, "@") {
+		if flavor&CronDescriptors == 0 {
+			return nil, errStringCron("descriptors are not allowed")
+		}
+		return parseCronDescriptor(str)
+	}
+
+	fields := strings.Fields(str)
+	switch {
+	case len(fields) == 5 && flavor&CronStandard != 0:
+		fields = append([]string{"0"}, fields...)
+	case len(fields) == 6 && flavor&CronSeconds != 0:
+	default:
+		return nil, errStringCron("unexpected number of fields")
+	}
+
+	schedule := &cronSchedule{
+		domStar:     fields[3] == "*" || fields[3] == "?",
+		weekdayStar: fields[5] == "*" || fields[5] == "?",
+	}
+	specs := []cronField{cronSecond, cronMinute, cronHour, cronDom, cronMonth, cronWeekday}
+	targets := []*uint64{&schedule.seconds, &schedule.minutes, &schedule.hours,
+		&schedule.doms, &schedule.months, &schedule.weekdays}
+	for i, field := range fields {
+		bits, err := parseCronField(field, specs[i], i == 3 || i == 5)
+		if err != nil {
+			return nil, err
+		}
+		*targets[i] = bits
+	}
+	// Sunday can be written as 0 or 7.
+	if schedule.weekdays&(1<<7) != 0 {
+		schedule.weekdays = schedule.weekdays&^(1<<7) | 1
+	}
+	// Schedules such as "0 0 31 2 *" are well-formed but never fire.
+	if schedule.dayGap = schedule.minDayGap(); schedule.dayGap == 0 {
+		return nil, errStringCron("schedule never fires")
+	}
+	return schedule, nil
+}
+
+// parseCronDescriptor : parses the predefined schedules and "@every <duration>".
+func parseCronDescriptor(str string) (*cronSchedule, error) {
+	if strings.HasPrefix(str, "@every ") {
+		every, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(str, "@every ")))
+		if err != nil || every <= 0 {
+			return nil, errStringCron("invalid @every duration")
+		}
+		return &cronSchedule{every: every}, nil
+	}
+	if str == "@reboot" {
+		return &cronSchedule{reboot: true}, nil
+	}
+	expression, exists := cronDescriptors[str]
+	if !exists {
+		return nil, errStringCron("unknown descriptor")
+	}
+	return parseCron(expression, CronStandard)
+}
+
+// parseCronField : parses the comma separated items of a field into a bit set.
+// Every item is "*", a value or a range "a-b", optionally followed by a step "/n".
+func parseCronField(field string, spec cronField, allowQuestion bool) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rangeStr, step := item, 1
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			value, err := strconv.Atoi(item[i+1:])
+			if err != nil || value < 1 || value > spec.max {
+				return 0, errStringCron("invalid step in the " + spec.name + " field")
+			}
+			rangeStr, step = item[:i], value
+		}
+
+		low, high := spec.min, spec.max
+		switch {
+		case rangeStr == "*" || (rangeStr == "?" && allowQuestion):
+		case strings.IndexByte(rangeStr, '-') > 0:
+			i := strings.IndexByte(rangeStr, '-')
+			var err1, err2 error
+			low, err1 = parseCronValue(rangeStr[:i], spec)
+			high, err2 = parseCronValue(rangeStr[i+1:], spec)
+			if err := orErr(err1, err2); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, errStringCron("descending range in the " + spec.name + " field")
+			}
+		default:
+			value, err := parseCronValue(rangeStr, spec)
+			if err != nil {
+				return 0, err
+			}
+			// A single value with a step, such as "5/15", runs until the end of the range.
+			low = value
+			if step == 1 {
+				high = value
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// parseCronValue : parses a number or a name (JAN-DEC, SUN-SAT, in any case) within the range of the field.
+func parseCronValue(str string, spec cronField) (int, error) {
+	for i, name := range spec.names {
+		if strings.EqualFold(str, name) {
+			return i + spec.min, nil
+		}
+	}
+	value, err := strconv.Atoi(str)
+	if err != nil || !isNumeric(str) {
+		return 0, errStringCron("invalid value in the " + spec.name + " field")
+	}
+	if value < spec.min || value > spec.max {
+		return 0, errStringCron(spec.name + " out of range")
+	}
+	return value, nil
+}
+
+// minInterval : the shortest time between two consecutive firings of the schedule.
+// It is math.MaxInt64 for "@reboot", which fires only once.
+func (c *cronSchedule) minInterval() time.Duration {
+	if c.reboot {
+		return math.MaxInt64
+	}
+	if c.every > 0 {
+		return c.every
+	}
+
+	// The firing times within a day are combined from the seconds upwards.
+	gap, first, last := cronBitsGap(c.seconds, 1, math.MaxInt64, 0, 0)
+	gap, first, last = cronBitsGap(c.minutes, 60, gap, first, last)
+	gap, first, last = cronBitsGap(c.hours, 3600, gap, first, last)
+
+	if acrossDays := int64(c.dayGap)*86400 - last + first; acrossDays < gap {
+		gap = acrossDays
+	}
+	return time.Duration(gap) * time.Second
+}
+
+// cronBitsGap : combines the values of a field, in units of the given seconds, with the smallest gap
+// and the first and last offsets of the finer fields. It returns the same for the combination.
+func cronBitsGap(bits uint64, unit int64, innerGap, innerFirst, innerLast int64) (int64, int64, int64) {
+	gap, first, last, previous := innerGap, int64(-1), int64(0), int64(-1)
+	for value := int64(0); value < 64; value++ {
+		if bits&(1<<uint(value)) == 0 {
+			continue
+		}
+		if previous >= 0 {
+			if between := (value-previous)*unit - innerLast + innerFirst; between < gap {
+				gap = between
+			}
+		} else {
+			first = value*unit + innerFirst
+		}
+		previous, last = value, value*unit+innerLast
+	}
+	return gap, first, last
+}
+
+// minDayGap : the smallest count of days between two consecutive firing days, found by walking
+// through a period of several leap years. It is 0 if fewer than two firing days exist in the period.
+func (c *cronSchedule) minDayGap() int {
+	day := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)
+	minGap, previous := 0, -1
+	for i := 0; day.Before(end); i, day = i+1, day.AddDate(0, 0, 1) {
+		if !c.firesOn(day) {
+			continue
+		}
+		if previous >= 0 && (minGap == 0 || i-previous < minGap) {
+			minGap = i - previous
+		}
+		if previous = i; minGap == 1 {
+			break
+		}
+	}
+	return minGap
+}
+
+// firesOn : checks if the schedule fires on the given day.
+func (c *cronSchedule) firesOn(day time.Time) bool {
+	if c.months&(1<<uint(day.Month())) == 0 {
+		return false
+	}
+	domMatch := c.doms&(1<<uint(day.Day())) != 0
+	weekdayMatch := c.weekdays&(1<<uint(day.Weekday())) != 0
+	if c.domStar || c.weekdayStar {
+		return domMatch && weekdayMatch
+	}
+	return domMatch || weekdayMatch
+}
+
+// Cron : Adds a cron expression check on the string, accepting the given flavors.
+// Every field is checked for its range, steps and names.
+// Example: Cron(CronStandard | CronDescriptors)
+func (s *StringRule) Cron(flavor CronFlavor) *StringRule {
+	s.addCheck(CodeCron, nil, func(arg string) error {
+		_, err := parseCron(arg, flavor)
+		return err
+	})
+	return s
+}
+
+// CronMinInterval : Adds a cron expression check on the string, like Cron, which additionally requires
+// consecutive firings to be at least the given interval apart.
+// Invalid expressions are reported with CodeCron, and too frequent schedules with CodeCronInterval.
+// Example: CronMinInterval(CronStandard, time.Hour) rejects "*/30 * * * *".
+func (s *StringRule) CronMinInterval(flavor CronFlavor, interval time.Duration) *StringRule {
+	s.addCheck(CodeCronInterval, Params{"interval": interval}, func(arg string) error {
+		schedule, err := parseCron(arg, flavor)
+		if err != nil {
+			return coded(CodeCron, nil, err)
+		}
+		if schedule.minInterval() < interval {
+			return errStringCronInterval(interval)
+		}
+		return nil
+	})
+	return s
+}
@@ -0,0 +1,73 @@
+package valkyrie
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCron(t *testing.T) {
+	tests := []struct {
+		expression string
+		flavor     CronFlavor
+		want       bool
+	}{
+		{"*/5 * * * *", CronStandard, true},
+		{"0 9-17 * * MON-FRI", CronStandard, true},
+		{"0 0 29 2 *", CronStandard, true},
+		{"0 0 ? * 7", CronStandard, true},
+		{"30 0 0 1 jan *", CronSeconds, true},
+		{"@daily", CronDescriptors, true},
+		{"@every 90s", CronDescriptors, true},
+		{"@reboot", CronDescriptors, true},
+		{"0 0 31 2 *", CronStandard, false},
+		{"0 0 30 2 *", CronStandard, false},
+		{"0 0 31 4,6,9,11 *", CronStandard, false},
+		{"0 0 31 2 MON", CronStandard, true},
+		{"60 * * * *", CronStandard, false},
+		{"5-1 * * * *", CronStandard, false},
+		{"*/0 * * * *", CronStandard, false},
+		{"* * * *", CronStandard, false},
+		{"0 * * * * *", CronStandard, false},
+		{"@daily", CronStandard, false},
+		{"@every -1s", CronDescriptors, false},
+		{"@fortnightly", CronDescriptors, false},
+	}
+
+	for _, test := range tests {
+		if got := PureString().Cron(test.flavor).Apply(test.expression) == nil; got != test.want {
+			t.Errorf("Cron().Apply(%q) passed = %v, want %v", test.expression, got, test.want)
+		}
+	}
+}
+
+func TestCronMinInterval(t *testing.T) {
+	tests := []struct {
+		expression string
+		interval   time.Duration
+		// code : the code of the failure, or "" if the expression passes.
+		code string
+	}{
+		{"0 * * * *", time.Hour, ""},
+		{"*/30 * * * *", time.Hour, CodeCronInterval},
+		{"0 23,1 * * *", 3 * time.Hour, CodeCronInterval},
+		{"0 22 * * *", 24 * time.Hour, ""},
+		{"0 0 * * 0,1", 48 * time.Hour, CodeCronInterval},
+		{"0 0 29 2 *", 365 * 24 * time.Hour, ""},
+		{"@every 10m", time.Hour, CodeCronInterval},
+		{"@reboot", time.Hour, ""},
+		{"0 0 31 2 *", time.Hour, CodeCron},
+		{"61 * * * *", time.Hour, CodeCron},
+	}
+
+	for _, test := range tests {
+		err := PureString().CronMinInterval(CronStandard|CronDescriptors, test.interval).Apply(test.expression)
+		var validationErr *ValidationError
+		switch {
+		case test.code == "" && err != nil:
+			t.Errorf("CronMinInterval(%s).Apply(%q) = %v, want nil", test.interval, test.expression, err)
+		case test.code != "" && (!errors.As(err, &validationErr) || validationErr.Code != test.code):
+			t.Errorf("CronMinInterval(%s).Apply(%q) = %#v, want code %q", test.interval, test.expression, err, test.code)
+		}
+	}
+}
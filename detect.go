@@ -0,0 +1,210 @@
+package valkyrie
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DetectionKind : Represents a kind of sensitive data found by the NoSecrets and NoPII checks.
+type DetectionKind string
+
+// Secret kinds.
+const (
+	DetectAWSAccessKey DetectionKind = "aws_access_key"
+	DetectGitHubToken  DetectionKind = "github_token"
+	DetectSlackToken   DetectionKind = "slack_token"
+	DetectStripeKey    DetectionKind = "stripe_key"
+	DetectGoogleAPIKey DetectionKind = "google_api_key"
+	DetectPrivateKey   DetectionKind = "private_key"
+	DetectJWT          DetectionKind = "jwt"
+	DetectHighEntropy  DetectionKind = "high_entropy_token"
+)
+
+// PII kinds.
+const (
+	DetectEmail      DetectionKind = "email"
+	DetectPhone      DetectionKind = "phone"
+	DetectCreditCard DetectionKind = "credit_card"
+	DetectIBAN       DetectionKind = "iban"
+)
+
+// Detection : Represents an occurrence of sensitive data, with its byte offsets in the string.
+type Detection struct {
+	Kind       DetectionKind
+	Start, End int
+}
+
+// detector : finds the candidates of a kind with a regex and confirms them with an optional validator.
+type detector struct {
+	kind     DetectionKind
+	regex    *regexp.Regexp
+	validate func(candidate string) bool
+}
+
+// secretDetectors : the detectors of the well-known secret formats.
+var secretDetectors = []detector{
+	{DetectPrivateKey, regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY-----`), nil},
+	{DetectJWT, regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`), nil},
+	{DetectAWSAccessKey, regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA|AIPA)[0-9A-Z]{16}\b`), nil},
+	{DetectGitHubToken, regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{82})\b`), nil},
+	{DetectSlackToken, regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`), nil},
+	{DetectStripeKey, regexp.MustCompile(`\b[sr]k_(?:live|test)_[A-Za-z0-9]{16,}\b`), nil},
+	{DetectGoogleAPIKey, regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`), nil},
+	{DetectHighEntropy, regexp.MustCompile(`[A-Za-z0-9_+-]{20,}={0,2}`), isHighEntropyToken},
+}
+
+// piiDetectors : the detectors of personal data, in the order of their precedence for overlapping candidates.
+var piiDetectors = []detector{
+	{DetectEmail, regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`), nil},
+	{DetectIBAN, regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`), isValidIBAN},
+	{DetectCreditCard, regexp.MustCompile(`\b\d(?:[ -]?\d){11,18}\b`), func(c string) bool { return isValidCreditCard(c, nil) }},
+	{DetectPhone, regexp.MustCompile(`(?:\+|\b)\d[\d .()-]{5,18}\d\b`), isPhoneCandidate},
+}
+
+// Redact : Replaces every detection in the string with the replacement.
+// The string must be the one the detections were found in.
+func (d *DetectionError) Redact(str string, replacement string) string {
+	return Redact(str, d.Detections, replacement)
+}
+
+// Redact : Replaces every detection in the string with the replacement.
+func Redact(str string, detections []Detection, replacement string) string {
+	var builder strings.Builder
+	previous := 0
+	for _, detection := range sortedDetections(detections) {
+		if detection.Start < previous || detection.End > len(str) {
+			continue
+		}
+		builder.WriteString(str[previous:detection.Start])
+		builder.WriteString(replacement)
+		previous = detection.End
+	}
+	builder.WriteString(str[previous:])
+	return builder.String()
+}
+
+// DetectSecrets : Finds API keys, tokens, private keys and other high entropy strings.
+func DetectSecrets(str string) []Detection {
+	return detect(str, secretDetectors, nil)
+}
+
+// DetectPII : Finds personal data of the given kinds, or of all the PII kinds if none are given.
+func DetectPII(str string, kinds ...DetectionKind) []Detection {
+	return detect(str, piiDetectors, kinds)
+}
+
+// detect : runs all the detectors in order, skipping the candidates overlapping an earlier detection,
+// and keeps the detections of the given kinds, or all of them if none are given.
+// All detectors run regardless of the kinds so that, for example, a card number is not reported as a phone number.
+func detect(str string, detectors []detector, kinds []DetectionKind) []Detection {
+	var detections, kept []Detection
+	for _, d := range detectors {
+		for _, span := range d.regex.FindAllStringIndex(str, -1) {
+			if overlaps(detections, span[0], span[1]) {
+				continue
+			}
+			if d.validate != nil && !d.validate(str[span[0]:span[1]]) {
+				continue
+			}
+			detection := Detection{Kind: d.kind, Start: span[0], End: span[1]}
+			detections = append(detections, detection)
+			if containsKind(kinds, d.kind) {
+				kept = append(kept, detection)
+			}
+		}
+	}
+	return sortedDetections(kept)
+}
+
+// isHighEntropyToken : checks if the candidate mixes letters and digits with a Shannon entropy of at
+// least 3.5 bits per character, as random keys do and words or identifiers rarely do.
+// Candidates of only hex digits and hyphens, such as UUIDs and hashes, are identifiers rather than secrets.
+func isHighEntropyToken(candidate string) bool {
+	var letters, digits, nonHex bool
+	counts := map[rune]int{}
+	for _, r := range candidate {
+		letters = letters || (r|0x20 >= 'a' && r|0x20 <= 'z')
+		digits = digits || (r >= '0' && r <= '9')
+		nonHex = nonHex || (r != '-' && uuidHexTable[byte(r)] == 0xff)
+		counts[r]++
+	}
+	if !letters || !digits || !nonHex {
+		return false
+	}
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(len(candidate))
+		entropy -= p * math.Log2(p)
+	}
+	return entropy >= 3.5
+}
+
+// isPhoneCandidate : checks if the candidate is an international number, or a formatted national
+// number of 10 or more digits.
+func isPhoneCandidate(candidate string) bool {
+	if strings.HasPrefix(candidate, "+") {
+		return isPhoneNumber(candidate, "")
+	}
+	digits := 0
+	for i := 0; i < len(candidate); i++ {
+		if isDigit(candidate[i]) {
+			digits++
+		}
+	}
+	return digits >= 10 && digits <= 15 && strings.ContainsAny(candidate, " .()-")
+}
+
+func containsKind(kinds []DetectionKind, kind DetectionKind) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func overlaps(detections []Detection, start, end int) bool {
+	for _, detection := range detections {
+		if start < detection.End && detection.Start < end {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedDetections(detections []Detection) []Detection {
+	sorted := append([]Detection(nil), detections...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+	return sorted
+}
+
+// NoSecrets : Adds a check that the string contains no API keys, tokens, private keys or other high
+// entropy strings. The error wraps a *DetectionError listing what was found, use errors.As to retrieve it.
+func (s *StringRule) NoSecrets() *StringRule {
+	s.addCheck(CodeNoSecrets, nil, func(arg string) error {
+		if detections := DetectSecrets(arg); len(detections) > 0 {
+			return &DetectionError{Detections: detections}
+		}
+		return nil
+	})
+	return s
+}
+
+// NoPII : Adds a check that the string contains no personal data of the given kinds (DetectEmail,
+// DetectPhone, DetectCreditCard, DetectIBAN), or of any of them if none are given.
+// Card numbers must pass the Luhn checksum and IBANs the mod-97 checksum to be reported.
+// The error wraps a *DetectionError listing what was found, use errors.As to retrieve it.
+func (s *StringRule) NoPII(kinds ...DetectionKind) *StringRule {
+	s.addCheck(CodeNoPII, nil, func(arg string) error {
+		if detections := DetectPII(arg, kinds...); len(detections) > 0 {
+			return &DetectionError{Detections: detections}
+		}
+		return nil
+	})
+	return s
+}
@@ -0,0 +1,104 @@
+package valkyrie
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Base64 : Adds a base64 check on the string.
+// The string must be decodable by at least one of the provided encodings.
+// If no encoding is provided, base64.StdEncoding is used.
+// Example: Base64(base64.URLEncoding, base64.RawURLEncoding)
+func (s *StringRule) Base64(encodings ...*base64.Encoding) *StringRule {
+	if len(encodings) == 0 {
+		encodings = []*base64.Encoding{base64.StdEncoding}
+	}
+	decode := func(arg string) (interface{}, error) {
+		for _, enc := range encodings {
+			if decoded, err := enc.DecodeString(arg); err == nil {
+				return string(decoded), nil
+			}
+		}
+		return nil, errStringBase64()
+	}
+
+	s.decode = decode
+	s.addCheck(CodeBase64, nil, func(arg string) error {
+		_, err := decode(arg)
+		return err
+	})
+	return s
+}
+
+// Hex : Adds a hexadecimal check on the string.
+// The string must have an even length and contain only hex digits, in any letter case.
+func (s *StringRule) Hex() *StringRule {
+	decode := func(arg string) (interface{}, error) {
+		decoded, err := hex.DecodeString(arg)
+		if err != nil {
+			return nil, errStringHex()
+		}
+		return string(decoded), nil
+	}
+
+	s.decode = decode
+	s.addCheck(CodeHex, nil, func(arg string) error {
+		_, err := decode(arg)
+		return err
+	})
+	return s
+}
+
+// JSONString : Adds a check that the string is a valid JSON document.
+func (s *StringRule) JSONString() *StringRule {
+	decode := func(arg string) (interface{}, error) {
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(arg), &parsed); err != nil {
+			return nil, errStringJSON()
+		}
+		return parsed, nil
+	}
+
+	s.decode = decode
+	s.addCheck(CodeJSON, nil, func(arg string) error {
+		if !json.Valid([]byte(arg)) {
+			return errStringJSON()
+		}
+		return nil
+	})
+	return s
+}
+
+// Decoded : Validates the payload decoded by the most recent Base64, Hex or JSONString check
+// using the provided rule.
+// The decoded bytes of Base64 and Hex are passed as a string, so StringRule length checks
+// act upon the decoded size. The parsed value of JSONString is passed as produced by
+// encoding/json, so objects are map[string]interface{} and numbers are float64.
+// If no such check precedes Decoded, the string itself is passed to the rule.
+// The payload is decoded by the Decoded check itself, so the rule can be applied concurrently.
+// It panics if the rule is nil.
+// Example: PureString().Base64().Decoded(PureString().LenLTE(1024))
+func (s *StringRule) Decoded(rule Rule) *StringRule {
+	if isNilRule(rule) {
+		panic("valkyrie: nil rule for decoded payload")
+	}
+	decode := s.decode
+	s.AddCheck(func(arg string) error {
+		if decode == nil {
+			return rule.Apply(arg)
+		}
+		payload, err := decode(arg)
+		if err != nil {
+			return err
+		}
+		return rule.Apply(payload)
+	})
+	return s
+}
+
+// Then : Same as Decoded. It reads better after JSONString. It panics if the rule is nil.
+// Example: PureString().JSONString().Then(PureMap().Key("id", true, FloatInt()))
+func (s *StringRule) Then(rule Rule) *StringRule {
+	return s.Decoded(rule)
+}
@@ -0,0 +1,71 @@
+package valkyrie
+
+import (
+	"encoding/base64"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEncoding(t *testing.T) {
+	tests := []struct {
+		name string
+		rule *StringRule
+		arg  string
+		want bool
+	}{
+		{"base64", PureString().Base64(), "aGVsbG8=", true},
+		{"base64 invalid", PureString().Base64(), "aGVsbG8", false},
+		{"base64 raw url", PureString().Base64(base64.StdEncoding, base64.RawURLEncoding), "aGk_", true},
+		{"hex", PureString().Hex(), "DEADbeef", true},
+		{"hex odd length", PureString().Hex(), "abc", false},
+		{"hex invalid digit", PureString().Hex(), "zz", false},
+		{"json", PureString().JSONString(), `{"a":[1,2]}`, true},
+		{"json invalid", PureString().JSONString(), `{"a":`, false},
+		{"decoded base64", PureString().Base64().Decoded(PureString().LenLTE(5)), "aGVsbG8=", true},
+		{"decoded base64 too long", PureString().Base64().Decoded(PureString().LenLTE(4)), "aGVsbG8=", false},
+		{"decoded hex", PureString().Hex().Decoded(PureString().Allow("hi")), "6869", true},
+		{"decoded without encoding", PureString().Decoded(PureString().LenLTE(3)), "abcd", false},
+		{
+			name: "then json",
+			rule: PureString().JSONString().Then(PureMap().Key("id", true, FloatInt().GTE(1))),
+			arg:  `{"id": 7}`,
+			want: true,
+		},
+		{
+			name: "then json failing",
+			rule: PureString().JSONString().Then(PureMap().Key("id", true, FloatInt().GTE(1))),
+			arg:  `{"id": 0}`,
+		},
+	}
+
+	for _, test := range tests {
+		if got := test.rule.Apply(test.arg) == nil; got != test.want {
+			t.Errorf("%s: Apply(%q) passed = %v, want %v", test.name, test.arg, got, test.want)
+		}
+	}
+}
+
+func TestDecodedConcurrent(t *testing.T) {
+	rule := PureString().Base64().Decoded(PureString().LenLTE(5))
+	short, long := base64.StdEncoding.EncodeToString([]byte("abc")), base64.StdEncoding.EncodeToString([]byte("abcdefgh"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if err := rule.Apply(short); err != nil {
+					t.Errorf("Apply(%q) = %v, want nil", short, err)
+					return
+				}
+				if err := rule.Apply(long); err == nil || !strings.Contains(err.Error(), "5") {
+					t.Errorf("Apply(%q) = %v, want a length failure", long, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -3,13 +3,105 @@ package valkyrie
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
+// MultiError : Represents multiple errors reported together by a single check.
+type MultiError []error
+
+// Error : Joins the messages of all the errors.
+func (m MultiError) Error() string {
+	messages := make([]string, len(m))
+	for i, err := range m {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Is : Reports whether any of the errors matches the target, so errors.Is looks into all of them.
+func (m MultiError) Is(target error) bool {
+	for _, err := range m {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As : Finds the first of the errors that matches the target, so errors.As looks into all of them.
+func (m MultiError) As(target interface{}) bool {
+	for _, err := range m {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidationError : Represents a validation failure with a stable code and the path of the failing value.
+type ValidationError struct {
+	// Code : the stable code of the failure, such as CodeGTE.
+	Code string
+	// Params : the parameters of the failed check, such as "min" for CodeGTE.
+	Params Params
+	// Path : the map keys leading to the failing value, outermost first.
+	Path []string
+	// Message : the template replacing the message of the underlying error, if not empty.
+	Message string
+	// Stack : the stack trace of the recovered panic, for internal errors.
+	Stack []byte
+	// Err : the underlying error.
+	Err error
+}
+
+// Error : Returns the message template filled from the params and the path if there is one,
+// or else the message of the underlying error.
+func (v *ValidationError) Error() string {
+	if v.Message == "" {
+		return v.Err.Error()
+	}
+	return fillTemplate(v.Message, v.Params, v.Field())
+}
+
+// Field : Returns the path of the failing value joined with dots, or "value" if it is the root.
+func (v *ValidationError) Field() string {
+	if len(v.Path) == 0 {
+		return "value"
+	}
+	return strings.Join(v.Path, ".")
+}
+
+// Unwrap : Returns the underlying error.
+func (v *ValidationError) Unwrap() error {
+	return v.Err
+}
+
+// DetectionError : The error returned by the NoSecrets and NoPII checks, listing every detection.
+type DetectionError struct {
+	Detections []Detection
+}
+
+// Error : Lists the kinds and the spans of the detections.
+func (d *DetectionError) Error() string {
+	found := make([]string, len(d.Detections))
+	for i, detection := range d.Detections {
+		found[i] = fmt.Sprintf("%s [%d:%d]", detection.Kind, detection.Start, detection.End)
+	}
+	return errStringSensitive(strings.Join(found, ", ")).Error()
+}
+
 var (
 	errEmpty = errors.New("")
 	errBlind = errors.New("blind validation")
 
-	errBool = func(t string) error { return fmt.Errorf("value should follow: type %s && convertible to bool", t) }
+	errNotNull = func() error { return fmt.Errorf("value should follow: not null") }
+
+	errInternal = func(v interface{}) error { return fmt.Errorf("internal error during validation: %v", v) }
+
+	errBool        = func(t string) error { return fmt.Errorf("value should follow: type %s && convertible to bool", t) }
+	errBoolIsTrue  = func() error { return fmt.Errorf("value should follow: type bool && == true") }
+	errBoolIsFalse = func() error { return fmt.Errorf("value should follow: type bool && == false") }
 
 	errInt64     = func(t string) error { return fmt.Errorf("value should follow: type %s && convertible to int64", t) }
 	errIntGTE    = func(value int64) error { return fmt.Errorf("value should follow: type int64 && >= %d", value) }
@@ -25,6 +117,9 @@ var (
 	errFloatLT     = func(value float64) error { return fmt.Errorf("value should follow: type float64 && < %f", value) }
 	errFloatExcept = func(value float64) error { return fmt.Errorf("value should follow: type float64 && != %f", value) }
 
+	errFloatLatitude  = func() error { return fmt.Errorf("value should follow: type float64 && valid latitude") }
+	errFloatLongitude = func() error { return fmt.Errorf("value should follow: type float64 && valid longitude") }
+
 	errString        = func(t string) error { return fmt.Errorf("value should follow: type %s && convertible to string", t) }
 	errStringLenGTE  = func(value int64) error { return fmt.Errorf("value should follow: type: string && length >= %d", value) }
 	errStringLenLTE  = func(value int64) error { return fmt.Errorf("value should follow: type: string && length <= %d", value) }
@@ -32,8 +127,82 @@ var (
 	errStringLenLT   = func(value int64) error { return fmt.Errorf("value should follow: type: string && length < %d", value) }
 	errStringPattern = func(value string) error { return fmt.Errorf("value should follow: type string && pattern: %s", value) }
 	errStringUUIDv4  = func() error { return fmt.Errorf("value should follow: type string && valid UUIDv4") }
+	errStringUUID    = func(v string) error { return fmt.Errorf("value should follow: type string && valid UUID %s", v) }
+	errStringBase64  = func() error { return fmt.Errorf("value should follow: type string && valid base64") }
+	errStringHex     = func() error { return fmt.Errorf("value should follow: type string && valid hex") }
+	errStringJSON    = func() error { return fmt.Errorf("value should follow: type string && valid JSON") }
+	errStringCard    = func(v string) error { return fmt.Errorf("value should follow: type string && valid card %s", v) }
+	errStringIBAN    = func() error { return fmt.Errorf("value should follow: type string && valid IBAN") }
+	errStringBIC     = func() error { return fmt.Errorf("value should follow: type string && valid BIC") }
+	errStringISBN10  = func() error { return fmt.Errorf("value should follow: type string && valid ISBN-10") }
+	errStringISBN13  = func() error { return fmt.Errorf("value should follow: type string && valid ISBN-13") }
+	errStringEAN13   = func() error { return fmt.Errorf("value should follow: type string && valid EAN-13") }
 	errStringExcept  = func(value string) error { return fmt.Errorf("value should follow: type string && != %s", value) }
 
+	errStringCountry  = func() error { return fmt.Errorf("value should follow: type string && valid ISO 3166-1 code") }
+	errStringCurrency = func() error { return fmt.Errorf("value should follow: type string && valid ISO 4217 code") }
+	errStringLanguage = func() error { return fmt.Errorf("value should follow: type string && valid BCP 47 tag") }
+	errStringTimeZone = func() error { return fmt.Errorf("value should follow: type string && valid IANA time zone") }
+
+	errStringContainsAnyOf    = func() error { return fmt.Errorf("value should follow: type string && contains a listed term") }
+	errStringNotContainsAnyOf = func(t string) error { return fmt.Errorf("value should follow: type string && not contains %q", t) }
+
+	errStringE164       = func() error { return fmt.Errorf("value should follow: type string && valid E.164 number") }
+	errStringPhone      = func(r string) error { return fmt.Errorf("value should follow: type string && valid %s phone", r) }
+	errStringPostalCode = func(c string) error { return fmt.Errorf("value should follow: type string && valid %s postcode", c) }
+
+	errStringSemVer          = func() error { return fmt.Errorf("value should follow: type string && valid SemVer") }
+	errStringSemVerRange     = func() error { return fmt.Errorf("value should follow: type string && valid SemVer range") }
+	errStringSemVerSatisfies = func(r string) error { return fmt.Errorf("value should follow: SemVer && satisfies %s", r) }
+	errStringSemVerCompare   = func(op, v string) error { return fmt.Errorf("value should follow: SemVer && %s %s", op, v) }
+
+	errStringCron         = func(reason string) error { return fmt.Errorf("value should follow: valid cron: %s", reason) }
+	errStringCronInterval = func(d time.Duration) error { return fmt.Errorf("value should follow: cron && interval >= %s", d) }
+
+	errStringSensitive = func(found string) error {
+		return fmt.Errorf("value should follow: type string && no sensitive data, found: %s", found)
+	}
+
+	errStringJWT = func(r string) error { return fmt.Errorf("value should follow: type string && valid JWT: %s", r) }
+
+	errPasswordMinLength  = func(n int) error { return fmt.Errorf("password should have at least %d characters", n) }
+	errPasswordMaxLength  = func(n int) error { return fmt.Errorf("password should have at most %d characters", n) }
+	errPasswordClass      = func(n int, class string) error { return fmt.Errorf("password should have at least %d %s", n, class) }
+	errPasswordEntropy    = func(bits float64) error { return fmt.Errorf("password should have an entropy >= %.0f bits", bits) }
+	errPasswordRepeated   = func(n int) error { return fmt.Errorf("password should not repeat a character over %d times", n) }
+	errPasswordSequential = func(n int) error { return fmt.Errorf("password should not have sequences over %d characters", n) }
+	errPasswordUsername   = func() error { return fmt.Errorf("password should not contain the username") }
+	errPasswordBlocked    = func() error { return fmt.Errorf("password should not be a commonly used password") }
+
 	errMap           = func() error { return fmt.Errorf("value should follow: type map[string]interface{}") }
 	errMapKeyMissing = func(name string) error { return fmt.Errorf("required key '%s' is missing", name) }
+	errMapKeyNull    = func(name string) error { return fmt.Errorf("key '%s' should not be null", name) }
+	errMapKeyRuleNil = func(name string) error { return fmt.Errorf("nil rule for key '%s'", name) }
+
+	errSlice       = func() error { return fmt.Errorf("value should follow: type slice") }
+	errSliceLenGTE = func(value int64) error { return fmt.Errorf("value should follow: type slice && length >= %d", value) }
+	errSliceLenLTE = func(value int64) error { return fmt.Errorf("value should follow: type slice && length <= %d", value) }
+	errSliceUnique = func() error { return fmt.Errorf("value should follow: type slice && unique elements") }
+
+	errForm    = func() error { return fmt.Errorf("value should follow: type url.Values") }
+	errFormKey = func(key string) error { return fmt.Errorf("form key '%s' conflicts with another key", key) }
+
+	errFile              = func() error { return fmt.Errorf("value should follow: type file") }
+	errFileRead          = func(err error) error { return fmt.Errorf("value should follow: readable file: %v", err) }
+	errFileMaxSize       = func(n int64) error { return fmt.Errorf("value should follow: file && size <= %d bytes", n) }
+	errFileMinSize       = func(n int64) error { return fmt.Errorf("value should follow: file && size >= %d bytes", n) }
+	errFileMIME          = func(t string) error { return fmt.Errorf("value should follow: file && content type in %s", t) }
+	errFileExtension     = func(e string) error { return fmt.Errorf("value should follow: file && extension in %s", e) }
+	errFileName          = func(r string) error { return fmt.Errorf("value should follow: file && safe name, but %s", r) }
+	errFileImage         = func() error { return fmt.Errorf("value should follow: file && PNG, JPEG or GIF image") }
+	errFileMinDimensions = func(w, h int) error { return fmt.Errorf("value should follow: image && size >= %dx%d", w, h) }
+	errFileMaxDimensions = func(w, h int) error { return fmt.Errorf("value should follow: image && size <= %dx%d", w, h) }
+
+	errGeoJSON            = func(reason string) error { return fmt.Errorf("value should follow: valid GeoJSON: %s", reason) }
+	errGeoJSONType        = func(types []string) error { return fmt.Errorf("value should follow: GeoJSON of type %v", types) }
+	errGeoJSONWinding     = func() error { return fmt.Errorf("value should follow: GeoJSON && right-hand rule winding") }
+	errGeoJSONPolygon     = func() error { return fmt.Errorf("value should follow: GeoJSON && within the polygon") }
+	errGeoJSONBoundingBox = func(minLng, minLat, maxLng, maxLat float64) error {
+		return fmt.Errorf("value should follow: GeoJSON && within [%f, %f, %f, %f]", minLng, minLat, maxLng, maxLat)
+	}
 )
@@ -0,0 +1,467 @@
+package valkyrie
+
+import (
+	"bytes"
+	"image"
+	// Registers the decoders used by the dimension checks.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// FileCheck : Represents a function that performs a validation check on a file.
+type FileCheck func(file *FileInfo) error
+
+// FileRule : Rule interface implementation for uploaded files: a *multipart.FileHeader, an *os.File,
+// an Upload, or an io.Reader with a Size() int64 method such as *bytes.Reader.
+// Readers are consumed by the content checks, unless they implement io.Seeker, in which case
+// they are rewound after validation.
+type FileRule struct {
+	// whites : the list of whitelisted values for this rule.
+	whites whitelist
+	// checks : the list of checks to be performed as part of this rule.
+	checks []FileCheck
+	// null : how the rule treats null values.
+	null nullability
+	// safe : whether panics raised by the checks are recovered.
+	safe bool
+	// err : the error to be thrown if the rule fails.
+	err error
+}
+
+// Upload : Represents a file read from any reader, for a FileRule.
+type Upload struct {
+	// Name : the name of the file given by the client.
+	Name string
+	// Size : the size of the file in bytes.
+	Size int64
+	// ContentType : the content type given by the client, if any.
+	ContentType string
+	// Reader : the content of the file.
+	Reader io.Reader
+}
+
+// FileInfo : Represents the file being validated, as seen by the checks of a FileRule.
+type FileInfo struct {
+	// Name : the name of the file given by the client.
+	Name string
+	// Size : the size of the file in bytes.
+	Size int64
+	// ContentType : the content type given by the client, which should not be trusted.
+	ContentType string
+
+	// open : opens the content of the file.
+	open func() (io.Reader, error)
+	// source : the opened content, of which the bytes in consumed have been read.
+	source io.Reader
+	// consumed : the bytes read from the source so far, replayed to every reader.
+	consumed bytes.Buffer
+}
+
+// FileRule PRIMARY PUBLIC METHODS ##################################
+
+// Allow : Whitelists the provided values for a rule.
+// If the argument is one of the whitelisted values, no checks
+// will be performed upon it.
+func (f *FileRule) Allow(args ...interface{}) *FileRule {
+	f.whites.add(false, args...)
+	return f
+}
+
+// AllowLoose : Whitelists the provided values like Allow, but numbers match
+// numbers of any kind with the same value, e.g. int(5) matches int64(5) and float64(5).
+func (f *FileRule) AllowLoose(args ...interface{}) *FileRule {
+	f.whites.add(true, args...)
+	return f
+}
+
+// AddCheck : Adds a custom check function to the rule.
+func (f *FileRule) AddCheck(check FileCheck) *FileRule {
+	f.checks = append(f.checks, check)
+	return f
+}
+
+// WithError : Adds a custom error to the rule.
+// This custom error (if not nil) will be thrown on every check violation
+// instead of the original error.
+// It is returned as it is, without the code and the key path of the violation; MsgError keeps them.
+func (f *FileRule) WithError(err error) *FileRule {
+	f.err = err
+	return f
+}
+
+// Nullable : Makes the rule pass null values, i.e. nil or nil pointers.
+func (f *FileRule) Nullable() *FileRule {
+	f.null = nullAllowed
+	return f
+}
+
+// NotNull : Makes the rule fail null values, i.e. nil or nil pointers, with a dedicated error.
+func (f *FileRule) NotNull() *FileRule {
+	f.null = nullDenied
+	return f
+}
+
+// Recover : Makes the rule recover panics raised by its checks and nested rules,
+// and report them as a ValidationError with the CodeInternal code and the stack trace.
+func (f *FileRule) Recover() *FileRule {
+	f.safe = true
+	return f
+}
+
+// Msg : Replaces the message of the most recently added check with the template.
+// The template may refer to the params of the check, such as {max}, and to the key path as {field}.
+// Example: PureFile().MaxSize(1 << 20).Msg("{field} must be at most {max} bytes")
+func (f *FileRule) Msg(template string) *FileRule {
+	if check := f.lastCheck(); check != nil {
+		f.checks[len(f.checks)-1] = func(file *FileInfo) error { return withMessage(template, check(file)) }
+	}
+	return f
+}
+
+// MsgError : Replaces the error of the most recently added check with the provided error.
+// The code, the params and the key path of the failure are kept.
+func (f *FileRule) MsgError(err error) *FileRule {
+	if check := f.lastCheck(); check != nil {
+		f.checks[len(f.checks)-1] = func(file *FileInfo) error { return withError(err, check(file)) }
+	}
+	return f
+}
+
+// Apply : Applies the rule on a given argument.
+// Pointers, driver.Valuer implementations and types with a registered Converter
+// are unwrapped before validation, except for the pointer types the rule accepts.
+func (f *FileRule) Apply(arg interface{}) (err error) {
+	if f.safe {
+		defer recoverPanic(&err)
+	}
+	if f.isWhitelisted(arg) {
+		return nil
+	}
+	file, cleanup, ok := toFileInfo(arg)
+	if !ok {
+		arg, isNull := normalize(arg, "")
+		if isNull && f.null == nullAllowed {
+			return nil
+		}
+		if isNull && f.null == nullDenied {
+			return orErr(f.err, coded(CodeNotNull, nil, errNotNull()))
+		}
+		if file, cleanup, ok = toFileInfo(arg); !ok {
+			return orErr(f.err, coded(CodeType, Params{"type": "file"}, errFile()))
+		}
+	}
+	defer cleanup()
+
+	if err := f.performChecks(file); err != nil {
+		return orErr(f.err, err)
+	}
+	return nil
+}
+
+// Head : Returns up to n first bytes of the file.
+func (f *FileInfo) Head(n int) ([]byte, error) {
+	reader, err := f.Reader()
+	if err != nil {
+		return nil, err
+	}
+	head := make([]byte, n)
+	read, err := io.ReadFull(reader, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	return head[:read], nil
+}
+
+// Reader : Returns a reader of the content of the file from the beginning.
+// Every reader replays the bytes read by the previous ones, so the checks may each read the content.
+func (f *FileInfo) Reader() (io.Reader, error) {
+	if f.source == nil {
+		source, err := f.open()
+		if err != nil {
+			return nil, err
+		}
+		f.source = source
+	}
+	replay := bytes.NewReader(f.consumed.Bytes())
+	return io.MultiReader(replay, io.TeeReader(f.source, &f.consumed)), nil
+}
+
+// FileRule CONSTRUCTORS ############################################
+
+// PureFile : Creates a FileRule which expects the arg to be a *multipart.FileHeader, an *os.File,
+// an Upload or an io.Reader with a Size() int64 method.
+func PureFile() *FileRule {
+	return &FileRule{}
+}
+
+// FileRule PRIVATE METHODS #########################################
+
+// addCheck : adds a check whose errors are reported as a ValidationError with the code and the params.
+func (f *FileRule) addCheck(code string, params Params, check FileCheck) *FileRule {
+	return f.AddCheck(func(file *FileInfo) error { return coded(code, params, check(file)) })
+}
+
+// lastCheck : returns the most recently added check, or nil if there is none.
+func (f *FileRule) lastCheck() FileCheck {
+	if len(f.checks) == 0 {
+		return nil
+	}
+	return f.checks[len(f.checks)-1]
+}
+
+func (f *FileRule) isWhitelisted(value interface{}) bool {
+	return f.whites.contains(value)
+}
+
+func (f *FileRule) performChecks(arg *FileInfo) error {
+	for _, check := range f.checks {
+		if check == nil {
+			continue
+		}
+		if err := check(arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toFileInfo : converts the accepted file types into a FileInfo.
+// The cleanup function closes the opened content and rewinds seekable readers.
+func toFileInfo(arg interface{}) (*FileInfo, func(), bool) {
+	switch typed := arg.(type) {
+	case *multipart.FileHeader:
+		if typed == nil {
+			return nil, nil, false
+		}
+		file := &FileInfo{Name: typed.Filename, Size: typed.Size, ContentType: typed.Header.Get("Content-Type")}
+		var opened multipart.File
+		file.open = func() (io.Reader, error) {
+			var err error
+			opened, err = typed.Open()
+			return opened, err
+		}
+		return file, func() {
+			if opened != nil {
+				_ = opened.Close()
+			}
+		}, true
+	case *os.File:
+		if typed == nil {
+			return nil, nil, false
+		}
+		stat, err := typed.Stat()
+		if err != nil {
+			return nil, nil, false
+		}
+		return readerFileInfo(Upload{Name: filepath.Base(typed.Name()), Size: stat.Size(), Reader: typed})
+	case Upload:
+		return readerFileInfo(typed)
+	case *Upload:
+		if typed == nil {
+			return nil, nil, false
+		}
+		return readerFileInfo(*typed)
+	case interface {
+		io.Reader
+		Size() int64
+	}:
+		return readerFileInfo(Upload{Size: typed.Size(), Reader: typed})
+	}
+	return nil, nil, false
+}
+
+// readerFileInfo : creates a FileInfo reading the content of the upload,
+// whose reader is rewound after validation if it is an io.Seeker.
+func readerFileInfo(upload Upload) (*FileInfo, func(), bool) {
+	if upload.Reader == nil {
+		return nil, nil, false
+	}
+	file := &FileInfo{Name: upload.Name, Size: upload.Size, ContentType: upload.ContentType}
+	file.open = func() (io.Reader, error) { return upload.Reader, nil }
+
+	seeker, isSeeker := upload.Reader.(io.Seeker)
+	if !isSeeker {
+		return file, func() {}, true
+	}
+	offset, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return file, func() {}, true
+	}
+	return file, func() { _, _ = seeker.Seek(offset, io.SeekStart) }, true
+}
+
+// mediaTypeMatches : tells whether the media type, without params, matches the pattern,
+// which may end with a wildcard subtype such as "image/*".
+func mediaTypeMatches(mediaType string, pattern string) bool {
+	if index := strings.IndexByte(mediaType, ';'); index >= 0 {
+		mediaType = mediaType[:index]
+	}
+	mediaType, pattern = strings.TrimSpace(strings.ToLower(mediaType)), strings.ToLower(pattern)
+	if strings.HasSuffix(pattern, "/*") {
+		return strings.HasPrefix(mediaType, pattern[:len(pattern)-1])
+	}
+	return mediaType == pattern
+}
+
+// windowsReservedNames : the file names reserved by Windows, in any letter case and with any extension.
+var windowsReservedNames = map[string]struct{}{
+	"CON": {}, "PRN": {}, "AUX": {}, "NUL": {},
+	"COM1": {}, "COM2": {}, "COM3": {}, "COM4": {}, "COM5": {}, "COM6": {}, "COM7": {}, "COM8": {}, "COM9": {},
+	"LPT1": {}, "LPT2": {}, "LPT3": {}, "LPT4": {}, "LPT5": {}, "LPT6": {}, "LPT7": {}, "LPT8": {}, "LPT9": {},
+}
+
+// unsafeFilenameReason : returns why the file name is unsafe to store, or an empty string if it is safe.
+func unsafeFilenameReason(name string) string {
+	switch {
+	case name == "":
+		return "empty"
+	case len(name) > 255:
+		return "longer than 255 bytes"
+	case strings.ContainsAny(name, `/\`):
+		return "contains a path separator"
+	case strings.HasPrefix(name, "."):
+		return "hidden or relative"
+	case strings.HasSuffix(name, ".") || strings.HasSuffix(name, " "):
+		return "ends with a dot or a space"
+	case strings.ContainsAny(name, `<>:"|?*`):
+		return "contains a reserved character"
+	case strings.IndexFunc(name, unicode.IsControl) >= 0:
+		return "contains a control character"
+	}
+	base := name
+	if index := strings.IndexByte(base, '.'); index >= 0 {
+		base = base[:index]
+	}
+	if _, reserved := windowsReservedNames[strings.ToUpper(base)]; reserved {
+		return "reserved by Windows"
+	}
+	return ""
+}
+
+// FileRule UTILITY PUBLIC METHODS  #################################
+
+// MaxSize : Adds a '<=' check on the file size in bytes.
+func (f *FileRule) MaxSize(value int64) *FileRule {
+	f.addCheck(CodeFileMaxSize, Params{"max": value}, func(file *FileInfo) error {
+		if file.Size > value {
+			return errFileMaxSize(value)
+		}
+		return nil
+	})
+	return f
+}
+
+// MinSize : Adds a '>=' check on the file size in bytes.
+func (f *FileRule) MinSize(value int64) *FileRule {
+	f.addCheck(CodeFileMinSize, Params{"min": value}, func(file *FileInfo) error {
+		if file.Size < value {
+			return errFileMinSize(value)
+		}
+		return nil
+	})
+	return f
+}
+
+// AllowedMIME : Adds a check that the content type detected by http.DetectContentType from the first
+// 512 bytes is one of the given types. The content type given by the client is ignored.
+// Example: AllowedMIME("image/png", "image/jpeg"), AllowedMIME("image/*")
+func (f *FileRule) AllowedMIME(types ...string) *FileRule {
+	text := strings.Join(types, ", ")
+	f.addCheck(CodeFileType, Params{"types": text}, func(file *FileInfo) error {
+		head, err := file.Head(512)
+		if err != nil {
+			return errFileRead(err)
+		}
+		detected := http.DetectContentType(head)
+		for _, allowed := range types {
+			if mediaTypeMatches(detected, allowed) {
+				return nil
+			}
+		}
+		return errFileMIME(text)
+	})
+	return f
+}
+
+// Extensions : Adds a check that the file name has one of the given extensions, in any letter case.
+// Example: Extensions(".png", ".jpg", "jpeg")
+func (f *FileRule) Extensions(extensions ...string) *FileRule {
+	allowed := make(map[string]struct{}, len(extensions))
+	for _, extension := range extensions {
+		allowed["."+strings.TrimPrefix(strings.ToLower(extension), ".")] = struct{}{}
+	}
+	text := strings.Join(extensions, ", ")
+	f.addCheck(CodeFileExtension, Params{"extensions": text}, func(file *FileInfo) error {
+		if _, exists := allowed[strings.ToLower(filepath.Ext(file.Name))]; !exists {
+			return errFileExtension(text)
+		}
+		return nil
+	})
+	return f
+}
+
+// SafeFilename : Adds a check that the file name is safe to store as it is: not empty, at most 255 bytes,
+// without path separators, control or reserved characters, not hidden, not ending with a dot or a space
+// and not reserved by Windows, such as "CON.txt".
+func (f *FileRule) SafeFilename() *FileRule {
+	f.addCheck(CodeFileName, nil, func(file *FileInfo) error {
+		if reason := unsafeFilenameReason(file.Name); reason != "" {
+			return errFileName(reason)
+		}
+		return nil
+	})
+	return f
+}
+
+// MinDimensions : Adds a check that the file is a PNG, JPEG or GIF image at least as wide and as high
+// as the given dimensions in pixels.
+func (f *FileRule) MinDimensions(width, height int) *FileRule {
+	f.addCheck(CodeImageMinSize, Params{"width": width, "height": height}, func(file *FileInfo) error {
+		config, err := decodeImageConfig(file)
+		if err != nil {
+			return err
+		}
+		if config.Width < width || config.Height < height {
+			return errFileMinDimensions(width, height)
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxDimensions : Adds a check that the file is a PNG, JPEG or GIF image at most as wide and as high
+// as the given dimensions in pixels.
+func (f *FileRule) MaxDimensions(width, height int) *FileRule {
+	f.addCheck(CodeImageMaxSize, Params{"width": width, "height": height}, func(file *FileInfo) error {
+		config, err := decodeImageConfig(file)
+		if err != nil {
+			return err
+		}
+		if config.Width > width || config.Height > height {
+			return errFileMaxDimensions(width, height)
+		}
+		return nil
+	})
+	return f
+}
+
+// decodeImageConfig : decodes the dimensions of a PNG, JPEG or GIF image without decoding its pixels.
+func decodeImageConfig(file *FileInfo) (image.Config, error) {
+	reader, err := file.Reader()
+	if err != nil {
+		return image.Config{}, errFileRead(err)
+	}
+	config, _, err := image.DecodeConfig(reader)
+	if err != nil {
+		return image.Config{}, errFileImage()
+	}
+	return config, nil
+}
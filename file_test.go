@@ -0,0 +1,131 @@
+package valkyrie
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+func TestFileRule(t *testing.T) {
+	pngData := testPNG(t, 30, 20)
+	upload := func(name string, data []byte) Upload {
+		return Upload{Name: name, Size: int64(len(data)), Reader: io.MultiReader(bytes.NewReader(data))}
+	}
+
+	tests := []struct {
+		name string
+		rule *FileRule
+		arg  interface{}
+		// code : the code of the failure, or "" if the file passes.
+		code string
+	}{
+		{"png", PureFile().AllowedMIME("image/png").Extensions("png"), upload("a.PNG", pngData), ""},
+		{"wildcard type", PureFile().AllowedMIME("image/*"), upload("a.png", pngData), ""},
+		{"sniffed type", PureFile().AllowedMIME("image/png"), Upload{
+			Name: "a.png", Size: 5, ContentType: "image/png", Reader: strings.NewReader("hello"),
+		}, CodeFileType},
+		{"extension", PureFile().Extensions(".jpg", "jpeg"), upload("a.png", pngData), CodeFileExtension},
+		{"max size", PureFile().MaxSize(10), upload("a.png", pngData), CodeFileMaxSize},
+		{"min size", PureFile().MinSize(int64(len(pngData)) + 1), upload("a.png", pngData), CodeFileMinSize},
+		{"min dimensions", PureFile().MinDimensions(30, 20), upload("a.png", pngData), ""},
+		{"min dimensions failing", PureFile().MinDimensions(31, 20), upload("a.png", pngData), CodeImageMinSize},
+		{"max dimensions failing", PureFile().MaxDimensions(30, 19), upload("a.png", pngData), CodeImageMaxSize},
+		{"not an image", PureFile().MaxDimensions(30, 20), upload("a.txt", []byte("text")), CodeImageMaxSize},
+		{
+			name: "content read by several checks",
+			rule: PureFile().AllowedMIME("image/png").MinDimensions(1, 1).MaxDimensions(100, 100),
+			arg:  upload("a.png", pngData),
+		},
+		{"sized reader", PureFile().MinDimensions(1, 1), bytes.NewReader(pngData), ""},
+		{"unsafe name", PureFile().SafeFilename(), upload("../a.png", pngData), CodeFileName},
+		{"not a file", PureFile(), "a.png", CodeType},
+	}
+
+	for _, test := range tests {
+		err := test.rule.Apply(test.arg)
+		if test.code == "" {
+			if err != nil {
+				t.Errorf("%s: Apply() = %v, want nil", test.name, err)
+			}
+			continue
+		}
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Code != test.code {
+			t.Errorf("%s: Apply() = %#v, want code %q", test.name, err, test.code)
+		}
+	}
+}
+
+func TestFileRuleRewinds(t *testing.T) {
+	reader := bytes.NewReader(testPNG(t, 1, 1))
+	if err := PureFile().AllowedMIME("image/png").Apply(reader); err != nil {
+		t.Fatalf("Apply() = %v, want nil", err)
+	}
+	if reader.Len() != int(reader.Size()) {
+		t.Errorf("reader has %d unread bytes, want the reader rewound to %d", reader.Len(), reader.Size())
+	}
+}
+
+func TestFileRuleMultipart(t *testing.T) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("avatar", "avatar.png")
+	if err != nil {
+		t.Fatalf("CreateFormFile() error = %v", err)
+	}
+	_, _ = part.Write(testPNG(t, 8, 8))
+	_ = writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatalf("ReadForm() error = %v", err)
+	}
+	defer func() { _ = form.RemoveAll() }()
+
+	rule := PureFile().SafeFilename().AllowedMIME("image/png").MaxDimensions(8, 8)
+	if err := rule.Apply(form.File["avatar"][0]); err != nil {
+		t.Errorf("Apply() = %v, want nil", err)
+	}
+}
+
+func TestSafeFilename(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"report.pdf", true},
+		{"résumé 2024.docx", true},
+		{"", false},
+		{strings.Repeat("a", 256), false},
+		{"../etc/passwd", false},
+		{`dir\file.txt`, false},
+		{".htaccess", false},
+		{"file.", false},
+		{"file ", false},
+		{"what?.txt", false},
+		{"tab\t.txt", false},
+		{"CON.txt", false},
+		{"lpt1", false},
+		{"console.txt", true},
+	}
+
+	for _, test := range tests {
+		if got := unsafeFilenameReason(test.name) == ""; got != test.want {
+			t.Errorf("unsafeFilenameReason(%q) safe = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+// testPNG : encodes a blank PNG image of the given dimensions.
+func testPNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,342 @@
+package valkyrie
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CardBrand : Represents a payment card brand, detected from the card number's prefix and length.
+type CardBrand string
+
+const (
+	// CardVisa : Visa cards, starting with 4 and 13, 16 or 19 digits long.
+	CardVisa CardBrand = "visa"
+	// CardMastercard : Mastercard cards, starting with 51-55 or 2221-2720 and 16 digits long.
+	CardMastercard CardBrand = "mastercard"
+	// CardAmex : American Express cards, starting with 34 or 37 and 15 digits long.
+	CardAmex CardBrand = "amex"
+	// CardDiscover : Discover cards, starting with 6011, 644-649, 65 or 622126-622925 and 16 to 19 digits long.
+	CardDiscover CardBrand = "discover"
+	// CardJCB : JCB cards, starting with 3528-3589 and 16 to 19 digits long.
+	CardJCB CardBrand = "jcb"
+	// CardDinersClub : Diners Club cards, starting with 300-305, 3095, 36 or 38-39 and 14 to 19 digits long.
+	CardDinersClub CardBrand = "diners_club"
+	// CardUnionPay : UnionPay cards, starting with 62 and 16 to 19 digits long.
+	CardUnionPay CardBrand = "unionpay"
+	// CardMaestro : Maestro cards, starting with one of the Maestro prefixes such as 5018 or 6759
+	// and 12 to 19 digits long.
+	CardMaestro CardBrand = "maestro"
+	// CardMir : Mir cards, starting with 2200-2204 and 16 to 19 digits long.
+	CardMir CardBrand = "mir"
+)
+
+// cardPrefix : an inclusive range of card number prefixes of the given digit count.
+type cardPrefix struct {
+	low, high int
+	digits    int
+}
+
+// cardSpec : the prefixes and the lengths of the numbers issued by a card brand.
+type cardSpec struct {
+	brand    CardBrand
+	prefixes []cardPrefix
+	lengths  []int
+}
+
+// cardSpecs : the table of known card brands, as published by the respective networks.
+var cardSpecs = []cardSpec{
+	{CardVisa, []cardPrefix{{4, 4, 1}}, []int{13, 16, 19}},
+	{CardMastercard, []cardPrefix{{51, 55, 2}, {2221, 2720, 4}}, []int{16}},
+	{CardAmex, []cardPrefix{{34, 34, 2}, {37, 37, 2}}, []int{15}},
+	{CardDiscover, []cardPrefix{{6011, 6011, 4}, {644, 649, 3}, {65, 65, 2}, {622126, 622925, 6}}, []int{16, 17, 18, 19}},
+	{CardJCB, []cardPrefix{{3528, 3589, 4}}, []int{16, 17, 18, 19}},
+	{CardDinersClub, []cardPrefix{{300, 305, 3}, {3095, 3095, 4}, {36, 36, 2}, {38, 39, 2}}, []int{14, 15, 16, 17, 18, 19}},
+	{CardUnionPay, []cardPrefix{{62, 62, 2}}, []int{16, 17, 18, 19}},
+	{CardMaestro, []cardPrefix{{5018, 5018, 4}, {5020, 5020, 4}, {5038, 5038, 4}, {5893, 5893, 4},
+		{6304, 6304, 4}, {6759, 6759, 4}, {6761, 6763, 4}}, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+	{CardMir, []cardPrefix{{2200, 2204, 4}}, []int{16, 17, 18, 19}},
+}
+
+// ibanLengths : the length of the IBAN of every country in the SWIFT IBAN registry.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BI": 27,
+	"BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28,
+	"EE": 20, "EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+	"GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "LY": 25,
+	"MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18,
+	"NO": 15, "OM": 23, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+	"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// collectDigits : copies the digits of str into buf, skipping the given separators.
+// It returns false if str contains any other character or has more digits than buf can hold.
+func collectDigits(str string, buf []byte, separators string) ([]byte, bool) {
+	n := 0
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		switch {
+		case c >= '0' && c <= '9':
+			if n == len(buf) {
+				return nil, false
+			}
+			buf[n] = c - '0'
+			n++
+		case strings.IndexByte(separators, c) >= 0:
+		default:
+			return nil, false
+		}
+	}
+	return buf[:n], true
+}
+
+// isLuhnValid : checks the Luhn (mod 10) checksum of the given digits.
+func isLuhnValid(digits []byte) bool {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i])
+		if (len(digits)-i)%2 == 0 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return len(digits) > 0 && sum%10 == 0
+}
+
+// matchesCardSpec : checks if the card digits carry one of the spec's prefixes and lengths.
+func matchesCardSpec(digits []byte, spec cardSpec) bool {
+	lengthOK := false
+	for _, length := range spec.lengths {
+		lengthOK = lengthOK || len(digits) == length
+	}
+	if !lengthOK {
+		return false
+	}
+	for _, prefix := range spec.prefixes {
+		value := 0
+		for _, d := range digits[:prefix.digits] {
+			value = value*10 + int(d)
+		}
+		if value >= prefix.low && value <= prefix.high {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidCreditCard : checks the Luhn checksum of the card number and that it belongs to one of the brands.
+// An empty list of brands means any known brand.
+func isValidCreditCard(str string, brands []CardBrand) bool {
+	var buf [19]byte
+	digits, ok := collectDigits(str, buf[:], " -")
+	if !ok || len(digits) < 12 || !isLuhnValid(digits) {
+		return false
+	}
+	for _, spec := range cardSpecs {
+		if !matchesCardSpec(digits, spec) {
+			continue
+		}
+		if len(brands) == 0 {
+			return true
+		}
+		for _, brand := range brands {
+			if brand == spec.brand {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isValidIBAN : checks the country specific length and the mod-97 checksum of an IBAN.
+// Spaces, as used in the print format, are ignored.
+func isValidIBAN(str string) bool {
+	iban := make([]byte, 0, 34)
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		switch {
+		case c == ' ':
+		case (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9'):
+			if len(iban) == cap(iban) {
+				return false
+			}
+			iban = append(iban, c)
+		default:
+			return false
+		}
+	}
+	if len(iban) < 4 || !isDigit(iban[2]) || !isDigit(iban[3]) {
+		return false
+	}
+	if length, exists := ibanLengths[string(iban[:2])]; !exists || length != len(iban) {
+		return false
+	}
+
+	// The first four characters are moved to the end and the letters are expanded to 10-35.
+	remainder := 0
+	for i := range iban {
+		c := iban[(i+4)%len(iban)]
+		if isDigit(c) {
+			remainder = (remainder*10 + int(c-'0')) % 97
+		} else {
+			remainder = (remainder*100 + int(c-'A'+10)) % 97
+		}
+	}
+	return remainder == 1
+}
+
+// isValidBIC : checks the structure of a BIC (ISO 9362), which is 8 or 11 uppercase characters.
+func isValidBIC(str string) bool {
+	if len(str) != 8 && len(str) != 11 {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		upper := c >= 'A' && c <= 'Z'
+		if i < 6 && !upper {
+			return false
+		}
+		if i >= 6 && !upper && !isDigit(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidISBN10 : checks the mod 11 checksum of an ISBN-10, whose last character may be 'X'.
+// Hyphens and spaces are ignored.
+func isValidISBN10(str string) bool {
+	if n := len(str); n > 0 && (str[n-1] == 'X' || str[n-1] == 'x') {
+		var buf [9]byte
+		digits, ok := collectDigits(str[:n-1], buf[:], " -")
+		return ok && len(digits) == 9 && isbn10Sum(digits)%11 == 1
+	}
+	var buf [10]byte
+	digits, ok := collectDigits(str, buf[:], " -")
+	return ok && len(digits) == 10 && (isbn10Sum(digits[:9])+int(digits[9]))%11 == 0
+}
+
+// isbn10Sum : the weighted sum of the first digits of an ISBN-10, weights starting at 10.
+func isbn10Sum(digits []byte) int {
+	sum := 0
+	for i, d := range digits {
+		sum += (10 - i) * int(d)
+	}
+	return sum
+}
+
+// isValidISBN13 : checks that an ISBN-13 is a valid EAN-13 with the 978 or 979 prefix.
+// Hyphens and spaces are ignored.
+func isValidISBN13(str string) bool {
+	var buf [13]byte
+	digits, ok := collectDigits(str, buf[:], " -")
+	if !ok || len(digits) != 13 || digits[0] != 9 || digits[1] != 7 || (digits[2] != 8 && digits[2] != 9) {
+		return false
+	}
+	return isEAN13ChecksumValid(digits)
+}
+
+// isValidEAN13 : checks the length and the checksum of an EAN-13, which must contain only digits.
+func isValidEAN13(str string) bool {
+	var buf [13]byte
+	digits, ok := collectDigits(str, buf[:], "")
+	return ok && len(digits) == 13 && isEAN13ChecksumValid(digits)
+}
+
+// isEAN13ChecksumValid : checks the alternating 1-3 weighted mod 10 checksum of 13 digits.
+func isEAN13ChecksumValid(digits []byte) bool {
+	sum := 0
+	for i, d := range digits {
+		if i%2 == 0 {
+			sum += int(d)
+		} else {
+			sum += 3 * int(d)
+		}
+	}
+	return sum%10 == 0
+}
+
+// cardBrandsText : describes the accepted card brands for error messages.
+func cardBrandsText(brands []CardBrand) string {
+	if len(brands) == 0 {
+		return "of any brand"
+	}
+	return fmt.Sprintf("of brands %v", brands)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// CreditCard : Adds a payment card number check on the string.
+// The number must pass the Luhn checksum and its prefix and length must belong to one of the
+// provided brands, or to any known brand if none are provided. Spaces and hyphens are ignored.
+func (s *StringRule) CreditCard(brands ...CardBrand) *StringRule {
+	text := cardBrandsText(brands)
+	s.addCheck(CodeCreditCard, Params{"brands": text}, func(arg string) error {
+		if !isValidCreditCard(arg, brands) {
+			return errStringCard(text)
+		}
+		return nil
+	})
+	return s
+}
+
+// IBAN : Adds an IBAN check on the string, verifying the country specific length and the
+// mod-97 checksum. Letters must be uppercase. Spaces are ignored.
+func (s *StringRule) IBAN() *StringRule {
+	s.addCheck(CodeIBAN, nil, func(arg string) error {
+		if !isValidIBAN(arg) {
+			return errStringIBAN()
+		}
+		return nil
+	})
+	return s
+}
+
+// BIC : Adds a BIC (SWIFT code) check on the string.
+func (s *StringRule) BIC() *StringRule {
+	s.addCheck(CodeBIC, nil, func(arg string) error {
+		if !isValidBIC(arg) {
+			return errStringBIC()
+		}
+		return nil
+	})
+	return s
+}
+
+// ISBN10 : Adds an ISBN-10 check on the string, verifying its checksum. Hyphens and spaces are ignored.
+func (s *StringRule) ISBN10() *StringRule {
+	s.addCheck(CodeISBN10, nil, func(arg string) error {
+		if !isValidISBN10(arg) {
+			return errStringISBN10()
+		}
+		return nil
+	})
+	return s
+}
+
+// ISBN13 : Adds an ISBN-13 check on the string, verifying its checksum. Hyphens and spaces are ignored.
+func (s *StringRule) ISBN13() *StringRule {
+	s.addCheck(CodeISBN13, nil, func(arg string) error {
+		if !isValidISBN13(arg) {
+			return errStringISBN13()
+		}
+		return nil
+	})
+	return s
+}
+
+// EAN13 : Adds an EAN-13 barcode check on the string, verifying its checksum.
+func (s *StringRule) EAN13() *StringRule {
+	s.addCheck(CodeEAN13, nil, func(arg string) error {
+		if !isValidEAN13(arg) {
+			return errStringEAN13()
+		}
+		return nil
+	})
+	return s
+}
@@ -0,0 +1,76 @@
+package valkyrie
+
+import "testing"
+
+func TestCreditCard(t *testing.T) {
+	tests := []struct {
+		number string
+		brands []CardBrand
+		want   bool
+	}{
+		{"4111111111111111", nil, true},
+		{"4111 1111 1111 1111", nil, true},
+		{"4111-1111-1111-1111", []CardBrand{CardVisa}, true},
+		{"4111111111111112", nil, false},
+		{"4111111111111111", []CardBrand{CardAmex}, false},
+		{"378282246310005", []CardBrand{CardAmex}, true},
+		{"5555555555554444", []CardBrand{CardMastercard}, true},
+		{"2223003122003222", []CardBrand{CardMastercard}, true},
+		{"6011111111111117", []CardBrand{CardDiscover}, true},
+		{"3530111333300000", []CardBrand{CardJCB}, true},
+		{"30569309025904", []CardBrand{CardDinersClub}, true},
+		{"6200000000000005", []CardBrand{CardUnionPay}, true},
+		{"0000000000000000", nil, false},
+		{"4111.1111.1111.1111", nil, false},
+		{"41111111111111111111", nil, false},
+		{"", nil, false},
+	}
+
+	for _, test := range tests {
+		if got := PureString().CreditCard(test.brands...).Apply(test.number) == nil; got != test.want {
+			t.Errorf("CreditCard(%v).Apply(%q) passed = %v, want %v", test.brands, test.number, got, test.want)
+		}
+	}
+}
+
+func TestFinancialIdentifiers(t *testing.T) {
+	tests := []struct {
+		name string
+		rule *StringRule
+		arg  string
+		want bool
+	}{
+		{"iban", PureString().IBAN(), "DE89370400440532013000", true},
+		{"iban print format", PureString().IBAN(), "GB82 WEST 1234 5698 7654 32", true},
+		{"iban with letters", PureString().IBAN(), "FR1420041010050500013M02606", true},
+		{"iban bad checksum", PureString().IBAN(), "GB82WEST12345698765431", false},
+		{"iban bad length", PureString().IBAN(), "DE8937040044053201300", false},
+		{"iban unknown country", PureString().IBAN(), "XX89370400440532013000", false},
+		{"iban lower case", PureString().IBAN(), "gb82west12345698765432", false},
+
+		{"bic", PureString().BIC(), "DEUTDEFF", true},
+		{"bic with branch", PureString().BIC(), "DEUTDEFF500", true},
+		{"bic lower case", PureString().BIC(), "deutdeff", false},
+		{"bic digit in bank code", PureString().BIC(), "DEUT1EFF", false},
+		{"bic bad length", PureString().BIC(), "DEUTDEF", false},
+
+		{"isbn10", PureString().ISBN10(), "0-306-40615-2", true},
+		{"isbn10 check x", PureString().ISBN10(), "080442957X", true},
+		{"isbn10 bad checksum", PureString().ISBN10(), "0306406153", false},
+		{"isbn10 x inside", PureString().ISBN10(), "08044X9572", false},
+
+		{"isbn13", PureString().ISBN13(), "978-0-306-40615-7", true},
+		{"isbn13 bad checksum", PureString().ISBN13(), "9780306406158", false},
+		{"isbn13 without the prefix", PureString().ISBN13(), "4006381333931", false},
+
+		{"ean13", PureString().EAN13(), "4006381333931", true},
+		{"ean13 bad checksum", PureString().EAN13(), "4006381333932", false},
+		{"ean13 with hyphens", PureString().EAN13(), "400-6381333931", false},
+	}
+
+	for _, test := range tests {
+		if got := test.rule.Apply(test.arg) == nil; got != test.want {
+			t.Errorf("%s: Apply(%q) passed = %v, want %v", test.name, test.arg, got, test.want)
+		}
+	}
+}
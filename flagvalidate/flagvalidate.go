@@ -0,0 +1,220 @@
+// Package flagvalidate validates the flags of a flag.FlagSet with valkyrie rules, along with
+// required flags and mutually exclusive groups, and reports the failures in the style of flag usage errors.
+package flagvalidate
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shivanshkc/valkyrie/v2"
+)
+
+// CodeExclusive : The code of the ValidationError reporting mutually exclusive flags that were set together.
+const CodeExclusive = "flag_exclusive"
+
+// Options : Represents the checks of the flags that are not expressed by the rule.
+type Options struct {
+	// Exclusive : the groups of flags of which at most one may be set.
+	Exclusive [][]string
+}
+
+// Error : Reports all the invalid flags at once.
+type Error struct {
+	// Errs : the failures, with the flag names as the paths of their ValidationErrors.
+	Errs valkyrie.MultiError
+}
+
+var (
+	errFlagRequired  = errors.New("flag should be set")
+	errFlagExclusive = func(names string) error { return fmt.Errorf("flags %s should not be set together", names) }
+	errFlagUndefined = func(name string) error { return fmt.Errorf("flag -%s is not defined", name) }
+)
+
+// Validate : Validates the flags of the parsed FlagSet with the rule, keyed by flag name.
+// The flags are validated with their default values if they are not set, so an invalid default is
+// reported like an invalid flag. But the required keys of the rule are the flags that must be set
+// on the command line; a default value does not count.
+// The values are passed as typed by flag.Getter: strings, bools, float64s, and int64s for the integer
+// flags, with durations in nanoseconds. Values of other types are passed as their String form.
+// All failures are returned together as an *Error, without changing the rule, as with MapRule.ApplyAll.
+// Example:
+//
+//	err := flagvalidate.Validate(fs, valkyrie.PureMap().
+//		Key("port", true, valkyrie.PureInt().GTE(1).LTE(65535)).
+//		Key("env", false, valkyrie.PureString().Allow("dev", "prod").Blind()),
+//		flagvalidate.Options{Exclusive: [][]string{{"json", "yaml"}}})
+func Validate(fs *flag.FlagSet, rule *valkyrie.MapRule, options Options) error {
+	set := map[string]interface{}{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = flagValue(f) })
+
+	var errs valkyrie.MultiError
+	for _, group := range options.Exclusive {
+		var names []string
+		for _, name := range group {
+			if fs.Lookup(name) == nil {
+				panic(fmt.Sprintf("valkyrie: %v", errFlagUndefined(name)))
+			}
+			if _, isSet := set[name]; isSet {
+				names = append(names, "-"+name)
+			}
+		}
+		if len(names) > 1 {
+			joined := strings.Join(names, ", ")
+			errs = append(errs, &valkyrie.ValidationError{
+				Code: CodeExclusive, Params: valkyrie.Params{"flags": joined}, Err: errFlagExclusive(joined),
+			})
+		}
+	}
+
+	if rule != nil {
+		var required valkyrie.MultiError
+		if err := rule.ApplyAll(set); err != nil {
+			required = requiredErrors(fs, err)
+		}
+		all := map[string]interface{}{}
+		fs.VisitAll(func(f *flag.Flag) { all[f.Name] = flagValue(f) })
+		if err := rule.ApplyAll(all); err != nil {
+			errs = append(errs, withoutFlags(flagErrors(err), required)...)
+		}
+		errs = append(errs, required...)
+	}
+
+	if len(errs) > 0 {
+		return &Error{Errs: errs}
+	}
+	return nil
+}
+
+// Parse : Parses the arguments with the FlagSet and validates the flags like Validate.
+// Validation failures are handled like parse errors: they are printed to the output of the FlagSet,
+// one per line, followed by its usage, and then the error handling of the FlagSet applies.
+func Parse(fs *flag.FlagSet, arguments []string, rule *valkyrie.MapRule, options Options) error {
+	if err := fs.Parse(arguments); err != nil {
+		return err
+	}
+	err := Validate(fs, rule, options)
+	if err == nil {
+		return nil
+	}
+
+	fmt.Fprintln(fs.Output(), strings.Join(err.(*Error).lines(), "\n"))
+	if fs.Usage != nil {
+		fs.Usage()
+	} else {
+		fmt.Fprintf(fs.Output(), "Usage of %s:\n", fs.Name())
+		fs.PrintDefaults()
+	}
+
+	switch fs.ErrorHandling() {
+	case flag.ExitOnError:
+		os.Exit(2)
+	case flag.PanicOnError:
+		panic(err)
+	}
+	return err
+}
+
+// Error : Lists the failures with their flag names.
+func (e *Error) Error() string {
+	return "invalid flags: " + strings.Join(e.lines(), "; ")
+}
+
+// Unwrap : Returns the failures, so they can be inspected with errors.Is and errors.As.
+// The renderers of valkyrie, such as RenderTextTree, should be given the Errs field.
+func (e *Error) Unwrap() error {
+	return e.Errs
+}
+
+// lines : returns the failures in the form "-name: message".
+func (e *Error) lines() []string {
+	lines := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		lines[i] = err.Error()
+		if validationErr, ok := err.(*valkyrie.ValidationError); ok && len(validationErr.Path) > 0 {
+			lines[i] = "-" + validationErr.Field() + ": " + lines[i]
+		}
+	}
+	return lines
+}
+
+// flagValue : returns the value of the flag, converted for the rules of valkyrie.
+func flagValue(f *flag.Flag) interface{} {
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return f.Value.String()
+	}
+	switch value := getter.Get().(type) {
+	case bool, string, float64, int64:
+		return value
+	case int:
+		return int64(value)
+	case uint:
+		if uint64(value) <= math.MaxInt64 {
+			return int64(value)
+		}
+	case uint64:
+		if value <= math.MaxInt64 {
+			return int64(value)
+		}
+	case time.Duration:
+		return int64(value)
+	}
+	return f.Value.String()
+}
+
+// requiredErrors : picks the failures of the rule that report defined flags which are required but not set.
+func requiredErrors(fs *flag.FlagSet, err error) valkyrie.MultiError {
+	var required valkyrie.MultiError
+	for _, err := range flagErrors(err) {
+		validationErr, ok := err.(*valkyrie.ValidationError)
+		if ok && validationErr.Code == valkyrie.CodeRequired && len(validationErr.Path) == 1 &&
+			fs.Lookup(validationErr.Path[0]) != nil {
+			required = append(required, err)
+		}
+	}
+	return required
+}
+
+// withoutFlags : drops the failures of the flags reported by the other failures,
+// so a required flag that is not set is not also reported for its default value.
+func withoutFlags(errs valkyrie.MultiError, others valkyrie.MultiError) valkyrie.MultiError {
+	reported := map[string]bool{}
+	for _, err := range others {
+		reported[err.(*valkyrie.ValidationError).Path[0]] = true
+	}
+	var kept valkyrie.MultiError
+	for _, err := range errs {
+		validationErr, ok := err.(*valkyrie.ValidationError)
+		if ok && len(validationErr.Path) > 0 && reported[validationErr.Path[0]] {
+			continue
+		}
+		kept = append(kept, err)
+	}
+	return kept
+}
+
+// flagErrors : splits the failures of the rule and reports missing required keys as flags that should be set.
+func flagErrors(err error) valkyrie.MultiError {
+	errs, ok := err.(valkyrie.MultiError)
+	if !ok {
+		errs = valkyrie.MultiError{err}
+	}
+
+	converted := make(valkyrie.MultiError, len(errs))
+	for i, err := range errs {
+		validationErr, ok := err.(*valkyrie.ValidationError)
+		if !ok || validationErr.Code != valkyrie.CodeRequired || len(validationErr.Path) != 1 {
+			converted[i] = err
+			continue
+		}
+		copied := *validationErr
+		copied.Err = errFlagRequired
+		converted[i] = &copied
+	}
+	return converted
+}
@@ -0,0 +1,129 @@
+package flagvalidate
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shivanshkc/valkyrie/v2"
+)
+
+func TestValidate(t *testing.T) {
+	rule := valkyrie.PureMap().
+		Key("port", true, valkyrie.PureInt().GTE(1).LTE(65535)).
+		Key("env", false, valkyrie.PureString().Allow("dev", "prod").Blind()).
+		Key("timeout", false, valkyrie.PureInt().LTE(int64(time.Minute))).
+		Key("workers", false, valkyrie.PureInt().GTE(1))
+	options := Options{Exclusive: [][]string{{"json", "yaml"}}}
+
+	tests := []struct {
+		name      string
+		arguments []string
+		// codes : the paths and the codes of the failures, or nil if the flags pass.
+		codes [][2]string
+	}{
+		{"valid", []string{"-port", "8080", "-env", "prod", "-timeout", "30s", "-workers", "4", "-json"}, nil},
+		{"required flag with a default", []string{}, [][2]string{{"port", valkyrie.CodeRequired}}},
+		{"invalid flag", []string{"-port", "0"}, [][2]string{{"port", valkyrie.CodeGTE}}},
+		{"duration in nanoseconds", []string{"-port", "1", "-timeout", "2m"}, [][2]string{{"timeout", valkyrie.CodeLTE}}},
+		{"uint flag", []string{"-port", "1", "-workers", "0"}, [][2]string{{"workers", valkyrie.CodeGTE}}},
+		{
+			name:      "all failures",
+			arguments: []string{"-json", "-yaml", "-env", "qa"},
+			codes:     [][2]string{{"value", CodeExclusive}, {"env", valkyrie.CodeBlind}, {"port", valkyrie.CodeRequired}},
+		},
+	}
+
+	for _, test := range tests {
+		fs := newFlagSet()
+		if err := fs.Parse(test.arguments); err != nil {
+			t.Fatalf("%s: Parse() error = %v", test.name, err)
+		}
+		err := Validate(fs, rule, options)
+		if test.codes == nil {
+			if err != nil {
+				t.Errorf("%s: Validate() = %v, want nil", test.name, err)
+			}
+			continue
+		}
+
+		var flagErr *Error
+		if !errors.As(err, &flagErr) {
+			t.Errorf("%s: Validate() = %#v, want an *Error", test.name, err)
+			continue
+		}
+		var codes [][2]string
+		for _, err := range flagErr.Errs {
+			var validationErr *valkyrie.ValidationError
+			if errors.As(err, &validationErr) {
+				codes = append(codes, [2]string{validationErr.Field(), validationErr.Code})
+			}
+		}
+		if !reflect.DeepEqual(codes, test.codes) {
+			t.Errorf("%s: Validate() failures = %v, want %v", test.name, codes, test.codes)
+		}
+	}
+}
+
+func TestValidateExclusive(t *testing.T) {
+	fs := newFlagSet()
+	_ = fs.Parse([]string{"-json", "-yaml"})
+
+	err := Validate(fs, nil, Options{Exclusive: [][]string{{"json", "yaml"}}})
+	var validationErr *valkyrie.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Params["flags"] != "-json, -yaml" {
+		t.Errorf("Validate() = %#v, want the flags param", err)
+	}
+	if want := "invalid flags: flags -json, -yaml should not be set together"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestValidateUndefinedExclusive(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Validate() did not panic for an undefined flag")
+		}
+	}()
+	_ = Validate(newFlagSet(), nil, Options{Exclusive: [][]string{{"json", "xml"}}})
+}
+
+func TestParse(t *testing.T) {
+	rule := valkyrie.PureMap().Key("port", true, valkyrie.PureInt().GTE(1))
+
+	fs := newFlagSet()
+	var output bytes.Buffer
+	fs.SetOutput(&output)
+	err := Parse(fs, []string{"-env", "dev"}, rule, Options{})
+
+	var flagErr *Error
+	if !errors.As(err, &flagErr) {
+		t.Fatalf("Parse() = %#v, want an *Error", err)
+	}
+	if !strings.HasPrefix(output.String(), "-port: flag should be set\nUsage of test:\n") {
+		t.Errorf("Parse() output = %q, want the failures followed by the usage", output.String())
+	}
+
+	fs = newFlagSet()
+	fs.SetOutput(ioutil.Discard)
+	if err := Parse(fs, []string{"-port", "80"}, rule, Options{}); err != nil {
+		t.Errorf("Parse() = %v, want nil", err)
+	}
+}
+
+// newFlagSet : returns a FlagSet with flags of several types that returns its errors.
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("port", 0, "the port to listen on")
+	fs.String("env", "dev", "the environment")
+	fs.Duration("timeout", time.Second, "the request timeout")
+	fs.Uint("workers", 1, "the count of workers")
+	fs.Bool("json", false, "log as JSON")
+	fs.Bool("yaml", false, "log as YAML")
+	return fs
+}
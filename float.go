@@ -8,9 +8,13 @@ type FloatRule struct {
 	// base : base is the name of the type from which the float value will be inferred.
 	base string
 	// whites : the list of whitelisted values for this rule.
-	whites []interface{}
+	whites whitelist
 	// checks : the list of checks to be performed as part of this rule.
 	checks []FloatCheck
+	// null : how the rule treats null values.
+	null nullability
+	// safe : whether panics raised by the checks are recovered.
+	safe bool
 	// err : the error to be thrown if the rule fails.
 	err error
 }
@@ -21,7 +25,14 @@ type FloatRule struct {
 // If the argument is one of the whitelisted values, no checks
 // will be performed upon it.
 func (f *FloatRule) Allow(args ...interface{}) *FloatRule {
-	f.whites = append(f.whites, args...)
+	f.whites.add(false, args...)
+	return f
+}
+
+// AllowLoose : Whitelists the provided values like Allow, but numbers match
+// numbers of any kind with the same value, e.g. int(5) matches int64(5) and float64(5).
+func (f *FloatRule) AllowLoose(args ...interface{}) *FloatRule {
+	f.whites.add(true, args...)
 	return f
 }
 
@@ -34,19 +45,70 @@ func (f *FloatRule) AddCheck(check FloatCheck) *FloatRule {
 // WithError : Adds a custom error to the rule.
 // This custom error (if not nil) will be thrown on every check violation
 // instead of the original error.
+// It is returned as it is, without the code and the key path of the violation; MsgError keeps them.
 func (f *FloatRule) WithError(err error) *FloatRule {
 	f.err = err
 	return f
 }
 
+// Nullable : Makes the rule pass null values, i.e. nil or nil pointers.
+func (f *FloatRule) Nullable() *FloatRule {
+	f.null = nullAllowed
+	return f
+}
+
+// NotNull : Makes the rule fail null values, i.e. nil or nil pointers, with a dedicated error.
+func (f *FloatRule) NotNull() *FloatRule {
+	f.null = nullDenied
+	return f
+}
+
+// Recover : Makes the rule recover panics raised by its checks and nested rules,
+// and report them as a ValidationError with the CodeInternal code and the stack trace.
+func (f *FloatRule) Recover() *FloatRule {
+	f.safe = true
+	return f
+}
+
+// Msg : Replaces the message of the most recently added check with the template.
+// The template may refer to the params of the check, such as {min}, and to the key path as {field}.
+// Example: PureFloat().LTE(1).Msg("{field} must be at most {max}")
+func (f *FloatRule) Msg(template string) *FloatRule {
+	if check := f.lastCheck(); check != nil {
+		f.checks[len(f.checks)-1] = func(arg float64) error { return withMessage(template, check(arg)) }
+	}
+	return f
+}
+
+// MsgError : Replaces the error of the most recently added check with the provided error.
+// The code, the params and the key path of the failure are kept.
+func (f *FloatRule) MsgError(err error) *FloatRule {
+	if check := f.lastCheck(); check != nil {
+		f.checks[len(f.checks)-1] = func(arg float64) error { return withError(err, check(arg)) }
+	}
+	return f
+}
+
 // Apply : Applies the rule on a given argument.
-func (f *FloatRule) Apply(arg interface{}) error {
+// Pointers, driver.Valuer implementations and types with a registered Converter
+// are unwrapped before validation.
+func (f *FloatRule) Apply(arg interface{}) (err error) {
+	if f.safe {
+		defer recoverPanic(&err)
+	}
+	arg, isNull := normalize(arg, f.base)
 	if f.isWhitelisted(arg) {
 		return nil
 	}
+	if isNull && f.null == nullAllowed {
+		return nil
+	}
+	if isNull && f.null == nullDenied {
+		return orErr(f.err, coded(CodeNotNull, nil, errNotNull()))
+	}
 	floatVal, err := toFloat64(arg, f.base)
 	if err != nil {
-		return orErr(f.err, errFloat64(f.base))
+		return orErr(f.err, coded(CodeType, Params{"type": f.base}, errFloat64(f.base)))
 	}
 
 	if err := f.performChecks(floatVal); err != nil {
@@ -78,13 +140,21 @@ func PureFloat() *FloatRule {
 
 // FloatRule PRIVATE METHODS ########################################
 
-func (f *FloatRule) isWhitelisted(value interface{}) bool {
-	for _, white := range f.whites {
-		if white == value {
-			return true
-		}
+// addCheck : adds a check whose errors are reported as a ValidationError with the code and the params.
+func (f *FloatRule) addCheck(code string, params Params, check FloatCheck) *FloatRule {
+	return f.AddCheck(func(arg float64) error { return coded(code, params, check(arg)) })
+}
+
+// lastCheck : returns the most recently added check, or nil if there is none.
+func (f *FloatRule) lastCheck() FloatCheck {
+	if len(f.checks) == 0 {
+		return nil
 	}
-	return false
+	return f.checks[len(f.checks)-1]
+}
+
+func (f *FloatRule) isWhitelisted(value interface{}) bool {
+	return f.whites.contains(value)
 }
 
 func (f *FloatRule) performChecks(arg float64) error {
@@ -103,7 +173,7 @@ func (f *FloatRule) performChecks(arg float64) error {
 
 // GTE : Adds a '>=' check to the rule.
 func (f *FloatRule) GTE(value float64) *FloatRule {
-	f.AddCheck(func(arg float64) error {
+	f.addCheck(CodeGTE, Params{"min": value}, func(arg float64) error {
 		if arg < value {
 			return errFloatGTE(value)
 		}
@@ -114,7 +184,7 @@ func (f *FloatRule) GTE(value float64) *FloatRule {
 
 // LTE : Adds a '<=' check to the rule.
 func (f *FloatRule) LTE(value float64) *FloatRule {
-	f.AddCheck(func(arg float64) error {
+	f.addCheck(CodeLTE, Params{"max": value}, func(arg float64) error {
 		if arg > value {
 			return errFloatLTE(value)
 		}
@@ -125,7 +195,7 @@ func (f *FloatRule) LTE(value float64) *FloatRule {
 
 // GT : Adds a '>' check to the rule.
 func (f *FloatRule) GT(value float64) *FloatRule {
-	f.AddCheck(func(arg float64) error {
+	f.addCheck(CodeGT, Params{"min": value}, func(arg float64) error {
 		if arg <= value {
 			return errFloatGT(value)
 		}
@@ -136,7 +206,7 @@ func (f *FloatRule) GT(value float64) *FloatRule {
 
 // LT : Adds a '<' check to the rule.
 func (f *FloatRule) LT(value float64) *FloatRule {
-	f.AddCheck(func(arg float64) error {
+	f.addCheck(CodeLT, Params{"max": value}, func(arg float64) error {
 		if arg >= value {
 			return errFloatLT(value)
 		}
@@ -147,7 +217,7 @@ func (f *FloatRule) LT(value float64) *FloatRule {
 
 // Except : Invalidates if arg == provided value
 func (f *FloatRule) Except(value float64) *FloatRule {
-	f.AddCheck(func(arg float64) error {
+	f.addCheck(CodeExcept, Params{"value": value}, func(arg float64) error {
 		if arg == value {
 			return errFloatExcept(value)
 		}
@@ -0,0 +1,279 @@
+package valkyrie
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FormRule : Rule interface implementation for url.Values, such as query strings and
+// application/x-www-form-urlencoded bodies. The values are expanded by ExpandForm
+// and validated like a map[string]interface{}.
+type FormRule struct {
+	// whites : the list of whitelisted values for this rule.
+	whites whitelist
+	// fields : the rule applied to the expanded values.
+	fields *MapRule
+	// null : how the rule treats null values.
+	null nullability
+	// safe : whether panics raised by the checks are recovered.
+	safe bool
+	// err : the error to be thrown if the rule fails.
+	err error
+}
+
+// FormRule PRIMARY PUBLIC METHODS ##################################
+
+// Allow : Whitelists the provided values for a rule.
+// If the argument is one of the whitelisted values, no checks
+// will be performed upon it. Forms are compared by deep equality.
+func (f *FormRule) Allow(args ...interface{}) *FormRule {
+	f.whites.add(false, args...)
+	return f
+}
+
+// AllowLoose : Whitelists the provided values like Allow, but numbers match
+// numbers of any kind with the same value, e.g. int(5) matches int64(5) and float64(5).
+func (f *FormRule) AllowLoose(args ...interface{}) *FormRule {
+	f.whites.add(true, args...)
+	return f
+}
+
+// AddCheck : Adds a custom check function to the rule, which receives the expanded values.
+func (f *FormRule) AddCheck(check MapCheck) *FormRule {
+	f.fields.AddCheck(check)
+	return f
+}
+
+// WithError : Adds a custom error to the rule.
+// This custom error (if not nil) will be thrown on every check violation
+// instead of the original error.
+// It is returned as it is, without the code and the key path of the violation; MsgError keeps them.
+func (f *FormRule) WithError(err error) *FormRule {
+	f.err = err
+	return f
+}
+
+// Nullable : Makes the rule pass null values, i.e. nil or nil pointers.
+func (f *FormRule) Nullable() *FormRule {
+	f.null = nullAllowed
+	return f
+}
+
+// NotNull : Makes the rule fail null values, i.e. nil or nil pointers, with a dedicated error.
+func (f *FormRule) NotNull() *FormRule {
+	f.null = nullDenied
+	return f
+}
+
+// Recover : Makes the rule recover panics raised by its checks and nested rules,
+// and report them as a ValidationError with the CodeInternal code and the stack trace.
+func (f *FormRule) Recover() *FormRule {
+	f.safe = true
+	f.fields.Recover()
+	return f
+}
+
+// Msg : Replaces the message of the most recently added check with the template.
+// The template may refer to the params of the check, such as {min}, and to the key path as {field}.
+// Example: PureForm().Key("page", true, StringInt()).Msg("{field} must be a page number")
+func (f *FormRule) Msg(template string) *FormRule {
+	f.fields.Msg(template)
+	return f
+}
+
+// MsgError : Replaces the error of the most recently added check with the provided error.
+// The code, the params and the key path of the failure are kept.
+func (f *FormRule) MsgError(err error) *FormRule {
+	f.fields.MsgError(err)
+	return f
+}
+
+// Apply : Applies the rule on a given argument.
+// Pointers, driver.Valuer implementations and types with a registered Converter
+// are unwrapped before validation.
+func (f *FormRule) Apply(arg interface{}) (err error) {
+	if f.safe {
+		defer recoverPanic(&err)
+	}
+	arg, isNull := normalize(arg, "")
+	if f.isWhitelisted(arg) {
+		return nil
+	}
+	if isNull && f.null == nullAllowed {
+		return nil
+	}
+	if isNull && f.null == nullDenied {
+		return orErr(f.err, coded(CodeNotNull, nil, errNotNull()))
+	}
+
+	var values map[string][]string
+	switch typed := arg.(type) {
+	case url.Values:
+		values = typed
+	case map[string][]string:
+		values = typed
+	default:
+		return orErr(f.err, coded(CodeType, Params{"type": "form"}, errForm()))
+	}
+	expanded, err := ExpandForm(values)
+	if err != nil {
+		return orErr(f.err, err)
+	}
+
+	if err := f.fields.Apply(expanded); err != nil {
+		return orErr(f.err, err)
+	}
+	return nil
+}
+
+// FormRule CONSTRUCTORS ############################################
+
+// PureForm : Creates a FormRule which expects the arg to be a url.Values or a map[string][]string.
+func PureForm() *FormRule {
+	return &FormRule{fields: PureMap()}
+}
+
+// ExpandForm : Expands the form values into a map for a MapRule.
+// A key given once becomes a string and a repeated key becomes a []interface{} of strings.
+// Bracket notation creates nested maps, such as "filter[status]=x" into {"filter": {"status": "x"}},
+// and slices when all keys of a level are indexes, such as "items[0][id]=3" into {"items": [{"id": "3"}]},
+// with the indexes compacted in order. Empty brackets, such as "tag[]=a", always create a slice.
+// Keys that collide, such as "a=1" and "a[b]=2", are reported with the CodeForm code.
+func ExpandForm(values map[string][]string) (map[string]interface{}, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	root := map[string]interface{}{}
+	for _, key := range keys {
+		if !insertFormValue(root, parseFormKey(key), values[key]) {
+			return nil, coded(CodeForm, Params{"key": key}, errFormKey(key))
+		}
+	}
+	for key, value := range root {
+		root[key] = indexedMapsToSlices(value)
+	}
+	return root, nil
+}
+
+// FormRule PRIVATE METHODS #########################################
+
+func (f *FormRule) isWhitelisted(value interface{}) bool {
+	return f.whites.contains(value)
+}
+
+// parseFormKey : splits a key in bracket notation into its segments, so "items[0][id]" becomes
+// ["items", "0", "id"] and "tag[]" becomes ["tag", ""]. Malformed keys are kept whole.
+func parseFormKey(key string) []string {
+	open := strings.IndexByte(key, '[')
+	if open <= 0 || !strings.HasSuffix(key, "]") {
+		return []string{key}
+	}
+
+	segments := []string{key[:open]}
+	rest := key[open:]
+	for rest != "" {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 {
+			return []string{key}
+		}
+		segments = append(segments, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return segments
+}
+
+// insertFormValue : inserts the values at the path of segments, creating the nested maps on the way.
+// It reports false if the path collides with another key.
+func insertFormValue(root map[string]interface{}, segments []string, values []string) bool {
+	asSlice := len(segments) > 1 && segments[len(segments)-1] == ""
+	if asSlice {
+		segments = segments[:len(segments)-1]
+	}
+
+	node := root
+	for _, segment := range segments[:len(segments)-1] {
+		if segment == "" {
+			return false
+		}
+		child, exists := node[segment]
+		if !exists {
+			child = map[string]interface{}{}
+			node[segment] = child
+		}
+		childMap, ok := child.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		node = childMap
+	}
+
+	leaf := segments[len(segments)-1]
+	if _, exists := node[leaf]; exists {
+		return false
+	}
+	if len(values) == 1 && !asSlice {
+		node[leaf] = values[0]
+		return true
+	}
+	items := make([]interface{}, len(values))
+	for i, value := range values {
+		items[i] = value
+	}
+	node[leaf] = items
+	return true
+}
+
+// indexedMapsToSlices : converts the nested maps whose keys are all indexes into slices, ordered by index.
+func indexedMapsToSlices(value interface{}) interface{} {
+	mapVal, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	for key, child := range mapVal {
+		mapVal[key] = indexedMapsToSlices(child)
+	}
+
+	indexes := make([]int, 0, len(mapVal))
+	for key := range mapVal {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || strconv.Itoa(index) != key {
+			return mapVal
+		}
+		indexes = append(indexes, index)
+	}
+	if len(indexes) == 0 {
+		return mapVal
+	}
+	sort.Ints(indexes)
+
+	items := make([]interface{}, len(indexes))
+	for i, index := range indexes {
+		items[i] = mapVal[strconv.Itoa(index)]
+	}
+	return items
+}
+
+// FormRule UTILITY PUBLIC METHODS  #################################
+
+// Key : Adds a check to a specific key of the expanded values.
+// Single values are strings, so scalar rules such as StringInt apply to them, while repeated keys
+// are slices, so a SliceRule applies to them; ScalarSlice accepts both.
+// It panics if the rule is nil.
+// Example: PureForm().Key("page", false, StringInt().GTE(1)).Key("tag", false, ScalarSlice().Each(PureString()))
+func (f *FormRule) Key(keyName string, required bool, rule Rule, options ...KeyOption) *FormRule {
+	f.fields.Key(keyName, required, rule, options...)
+	return f
+}
+
+// KeyFunc : Adds a check to a specific key of the expanded values, validated by the rule returned by ruleFunc.
+// It panics if ruleFunc is nil.
+func (f *FormRule) KeyFunc(keyName string, required bool, ruleFunc func(m map[string]interface{}) Rule,
+	options ...KeyOption) *FormRule {
+	f.fields.KeyFunc(keyName, required, ruleFunc, options...)
+	return f
+}
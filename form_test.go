@@ -0,0 +1,108 @@
+package valkyrie
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestExpandForm(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string][]string
+		want   map[string]interface{}
+		// code : the code of the failure, or "" if the values expand.
+		code string
+	}{
+		{
+			name:   "single and repeated keys",
+			values: map[string][]string{"a": {"1"}, "b": {"1", "2"}},
+			want:   map[string]interface{}{"a": "1", "b": []interface{}{"1", "2"}},
+		},
+		{
+			name:   "nested maps",
+			values: map[string][]string{"filter[status]": {"open"}, "filter[owner][id]": {"7"}},
+			want: map[string]interface{}{"filter": map[string]interface{}{
+				"status": "open", "owner": map[string]interface{}{"id": "7"},
+			}},
+		},
+		{
+			name:   "indexes become compacted slices",
+			values: map[string][]string{"items[0][id]": {"3"}, "items[5][id]": {"4"}, "items[10][id]": {"5"}},
+			want: map[string]interface{}{"items": []interface{}{
+				map[string]interface{}{"id": "3"}, map[string]interface{}{"id": "4"}, map[string]interface{}{"id": "5"},
+			}},
+		},
+		{
+			name:   "mixed keys stay a map",
+			values: map[string][]string{"m[0]": {"a"}, "m[x]": {"b"}, "n[01]": {"c"}},
+			want: map[string]interface{}{
+				"m": map[string]interface{}{"0": "a", "x": "b"}, "n": map[string]interface{}{"01": "c"},
+			},
+		},
+		{
+			name:   "empty brackets",
+			values: map[string][]string{"tag[]": {"a"}},
+			want:   map[string]interface{}{"tag": []interface{}{"a"}},
+		},
+		{
+			name:   "malformed keys are kept whole",
+			values: map[string][]string{"a[b": {"1"}, "[c]": {"2"}, "d[e]f": {"3"}},
+			want:   map[string]interface{}{"a[b": "1", "[c]": "2", "d[e]f": "3"},
+		},
+		{name: "collision", values: map[string][]string{"a": {"1"}, "a[b]": {"2"}}, code: CodeForm},
+		{name: "empty brackets inside", values: map[string][]string{"a[][b]": {"1"}}, code: CodeForm},
+	}
+
+	for _, test := range tests {
+		got, err := ExpandForm(test.values)
+		if test.code != "" {
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Code != test.code {
+				t.Errorf("%s: ExpandForm() error = %#v, want code %q", test.name, err, test.code)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: ExpandForm() = %v, %v, want %v", test.name, got, err, test.want)
+		}
+	}
+}
+
+func TestFormRule(t *testing.T) {
+	rule := PureForm().
+		Key("page", false, StringInt().GTE(1)).
+		Key("tag", false, ScalarSlice().LenLTE(2).Each(PureString().LenGTE(2))).
+		Key("items", false, PureSlice().Each(PureMap().Key("id", true, StringInt())))
+
+	tests := []struct {
+		name   string
+		values url.Values
+		// path : the path of the failure, or nil if the form passes.
+		path []string
+	}{
+		{"valid", url.Values{"page": {"2"}, "tag": {"ab"}, "items[0][id]": {"3"}}, nil},
+		{"repeated tag", url.Values{"tag": {"ab", "cd"}}, nil},
+		{"invalid page", url.Values{"page": {"0"}}, []string{"page"}},
+		{"too many tags", url.Values{"tag": {"ab", "cd", "ef"}}, []string{"tag"}},
+		{"invalid tag", url.Values{"tag": {"ab", "c"}}, []string{"tag", "1"}},
+		{"invalid item", url.Values{"items[0][id]": {"1"}, "items[1][id]": {"x"}}, []string{"items", "1", "id"}},
+		{"colliding keys", url.Values{"page": {"1"}, "page[x]": {"1"}}, []string{}},
+	}
+
+	for _, test := range tests {
+		err := rule.Apply(test.values)
+		if test.path == nil {
+			if err != nil {
+				t.Errorf("%s: Apply() = %v, want nil", test.name, err)
+			}
+			continue
+		}
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || len(validationErr.Path) != len(test.path) ||
+			(len(test.path) > 0 && !reflect.DeepEqual(validationErr.Path, test.path)) {
+			t.Errorf("%s: Apply() = %#v, want the path %v", test.name, err, test.path)
+		}
+	}
+}
@@ -0,0 +1,523 @@
+package valkyrie
+
+import "encoding/json"
+
+// GeoJSONCheck : Represents a function that performs a validation check on a GeoJSON object.
+type GeoJSONCheck func(obj map[string]interface{}) error
+
+// GeoJSONRule : Rule interface implementation for a GeoJSON (RFC 7946) object
+// decoded into a map[string]interface{}.
+type GeoJSONRule struct {
+	// whites : the list of whitelisted values for this rule.
+	whites whitelist
+	// types : the list of accepted top level object types. Empty means any type.
+	types []string
+	// checks : the list of checks to be performed as part of this rule.
+	checks []GeoJSONCheck
+	// null : how the rule treats null values.
+	null nullability
+	// safe : whether panics raised by the checks are recovered.
+	safe bool
+	// err : the error to be thrown if the rule fails.
+	err error
+}
+
+// geoPosition : a longitude and latitude pair. Altitudes are ignored.
+type geoPosition [2]float64
+
+// geoShape : the positions of a GeoJSON object, with the rings of its polygons kept apart for winding checks.
+type geoShape struct {
+	positions []geoPosition
+	polygons  [][][]geoPosition
+}
+
+// GeoJSON object types.
+const (
+	GeoPoint              = "Point"
+	GeoMultiPoint         = "MultiPoint"
+	GeoLineString         = "LineString"
+	GeoMultiLineString    = "MultiLineString"
+	GeoPolygon            = "Polygon"
+	GeoMultiPolygon       = "MultiPolygon"
+	GeoGeometryCollection = "GeometryCollection"
+	GeoFeature            = "Feature"
+	GeoFeatureCollection  = "FeatureCollection"
+)
+
+// GeoJSONRule PRIMARY PUBLIC METHODS ###############################
+
+// Allow : Whitelists the provided values for a rule.
+// If the argument is one of the whitelisted values, no checks
+// will be performed upon it. Objects are compared by deep equality.
+func (g *GeoJSONRule) Allow(args ...interface{}) *GeoJSONRule {
+	g.whites.add(false, args...)
+	return g
+}
+
+// AllowLoose : Whitelists the provided values like Allow, but numbers match
+// numbers of any kind with the same value, e.g. int(5) matches int64(5) and float64(5).
+func (g *GeoJSONRule) AllowLoose(args ...interface{}) *GeoJSONRule {
+	g.whites.add(true, args...)
+	return g
+}
+
+// AddCheck : Adds a custom check function to the rule.
+func (g *GeoJSONRule) AddCheck(check GeoJSONCheck) *GeoJSONRule {
+	g.checks = append(g.checks, check)
+	return g
+}
+
+// WithError : Adds a custom error to the rule.
+// This custom error (if not nil) will be thrown on every check violation
+// instead of the original error.
+// It is returned as it is, without the code and the key path of the violation; MsgError keeps them.
+func (g *GeoJSONRule) WithError(err error) *GeoJSONRule {
+	g.err = err
+	return g
+}
+
+// Nullable : Makes the rule pass null values, i.e. nil or nil pointers.
+func (g *GeoJSONRule) Nullable() *GeoJSONRule {
+	g.null = nullAllowed
+	return g
+}
+
+// NotNull : Makes the rule fail null values, i.e. nil or nil pointers, with a dedicated error.
+func (g *GeoJSONRule) NotNull() *GeoJSONRule {
+	g.null = nullDenied
+	return g
+}
+
+// Recover : Makes the rule recover panics raised by its checks and nested rules,
+// and report them as a ValidationError with the CodeInternal code and the stack trace.
+func (g *GeoJSONRule) Recover() *GeoJSONRule {
+	g.safe = true
+	return g
+}
+
+// Msg : Replaces the message of the most recently added check with the template.
+// The template may refer to the params of the check, such as {min}, and to the key path as {field}.
+// Example: GeoJSON().WindingOrder().Msg("{field} must follow the right-hand rule")
+func (g *GeoJSONRule) Msg(template string) *GeoJSONRule {
+	if check := g.lastCheck(); check != nil {
+		g.checks[len(g.checks)-1] = func(obj map[string]interface{}) error { return withMessage(template, check(obj)) }
+	}
+	return g
+}
+
+// MsgError : Replaces the error of the most recently added check with the provided error.
+// The code, the params and the key path of the failure are kept.
+func (g *GeoJSONRule) MsgError(err error) *GeoJSONRule {
+	if check := g.lastCheck(); check != nil {
+		g.checks[len(g.checks)-1] = func(obj map[string]interface{}) error { return withError(err, check(obj)) }
+	}
+	return g
+}
+
+// Apply : Applies the rule on a given argument.
+// Pointers, driver.Valuer implementations and types with a registered Converter
+// are unwrapped before validation.
+func (g *GeoJSONRule) Apply(arg interface{}) (err error) {
+	if g.safe {
+		defer recoverPanic(&err)
+	}
+	arg, isNull := normalize(arg, "")
+	if g.isWhitelisted(arg) {
+		return nil
+	}
+	if isNull && g.null == nullAllowed {
+		return nil
+	}
+	if isNull && g.null == nullDenied {
+		return orErr(g.err, coded(CodeNotNull, nil, errNotNull()))
+	}
+	obj, ok := arg.(map[string]interface{})
+	if !ok {
+		return orErr(g.err, coded(CodeGeoJSON, nil, errGeoJSON("not an object")))
+	}
+	if !g.isTypeAllowed(obj["type"]) {
+		return orErr(g.err, coded(CodeGeoJSONType, Params{"types": g.types}, errGeoJSONType(g.types)))
+	}
+	if err := parseGeoObject(obj, &geoShape{}, true); err != nil {
+		return orErr(g.err, coded(CodeGeoJSON, nil, err))
+	}
+
+	if err := g.performChecks(obj); err != nil {
+		return orErr(g.err, err)
+	}
+	return nil
+}
+
+// GeoJSONRule CONSTRUCTORS #########################################
+
+// GeoJSON : Creates a GeoJSONRule which expects the arg to be a GeoJSON object of one of the given
+// types, or of any type if none are given. The structure, the positions' ranges and the closure of
+// polygon rings are always validated.
+// Example: GeoJSON(GeoPolygon, GeoMultiPolygon)
+func GeoJSON(types ...string) *GeoJSONRule {
+	return &GeoJSONRule{types: types}
+}
+
+// LatLng : Creates a MapRule which expects the arg to have a "lat" latitude and a "lng" longitude.
+func LatLng() *MapRule {
+	return PureMap().
+		Key("lat", true, PureFloat().Latitude()).
+		Key("lng", true, PureFloat().Longitude())
+}
+
+// GeoJSONRule PRIVATE METHODS ######################################
+
+// addCheck : adds a check whose errors are reported as a ValidationError with the code and the params.
+func (g *GeoJSONRule) addCheck(code string, params Params, check GeoJSONCheck) *GeoJSONRule {
+	return g.AddCheck(func(obj map[string]interface{}) error { return coded(code, params, check(obj)) })
+}
+
+// lastCheck : returns the most recently added check, or nil if there is none.
+func (g *GeoJSONRule) lastCheck() GeoJSONCheck {
+	if len(g.checks) == 0 {
+		return nil
+	}
+	return g.checks[len(g.checks)-1]
+}
+
+func (g *GeoJSONRule) isWhitelisted(value interface{}) bool {
+	return g.whites.contains(value)
+}
+
+func (g *GeoJSONRule) isTypeAllowed(objType interface{}) bool {
+	if len(g.types) == 0 {
+		return true
+	}
+	for _, t := range g.types {
+		if t == objType {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GeoJSONRule) performChecks(arg map[string]interface{}) error {
+	for _, check := range g.checks {
+		if check == nil {
+			continue
+		}
+		if err := check(arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GeoJSONRule UTILITY PUBLIC METHODS  ##############################
+
+// WindingOrder : Adds a check that polygons follow the right-hand rule of RFC 7946:
+// exterior rings are counterclockwise and holes are clockwise.
+func (g *GeoJSONRule) WindingOrder() *GeoJSONRule {
+	g.addCheck(CodeWindingOrder, nil, func(obj map[string]interface{}) error {
+		shape := &geoShape{}
+		_ = parseGeoObject(obj, shape, true)
+		for _, polygon := range shape.polygons {
+			for i, ring := range polygon {
+				if area := ringArea(ring); (i == 0 && area < 0) || (i > 0 && area > 0) {
+					return errGeoJSONWinding()
+				}
+			}
+		}
+		return nil
+	})
+	return g
+}
+
+// WithinBoundingBox : Adds a check that every position lies within the given box, edges included.
+func (g *GeoJSONRule) WithinBoundingBox(minLng, minLat, maxLng, maxLat float64) *GeoJSONRule {
+	g.addCheck(CodeBoundingBox, Params{"minLng": minLng, "minLat": minLat, "maxLng": maxLng, "maxLat": maxLat}, func(obj map[string]interface{}) error {
+		shape := &geoShape{}
+		_ = parseGeoObject(obj, shape, true)
+		for _, p := range shape.positions {
+			if p[0] < minLng || p[0] > maxLng || p[1] < minLat || p[1] > maxLat {
+				return errGeoJSONBoundingBox(minLng, minLat, maxLng, maxLat)
+			}
+		}
+		return nil
+	})
+	return g
+}
+
+// WithinPolygon : Adds a check that every position lies inside the polygon formed by the given ring
+// of [longitude, latitude] pairs. The ring is closed implicitly.
+func (g *GeoJSONRule) WithinPolygon(ring [][2]float64) *GeoJSONRule {
+	polygon := make([]geoPosition, len(ring))
+	for i, p := range ring {
+		polygon[i] = p
+	}
+	g.addCheck(CodeWithinPolygon, nil, func(obj map[string]interface{}) error {
+		shape := &geoShape{}
+		_ = parseGeoObject(obj, shape, true)
+		for _, p := range shape.positions {
+			if !isPointInRing(p, polygon) {
+				return errGeoJSONPolygon()
+			}
+		}
+		return nil
+	})
+	return g
+}
+
+// Latitude : Adds a check that the value is a latitude, i.e. within [-90, 90].
+func (f *FloatRule) Latitude() *FloatRule {
+	f.addCheck(CodeLatitude, nil, func(arg float64) error {
+		if !(arg >= -90 && arg <= 90) {
+			return errFloatLatitude()
+		}
+		return nil
+	})
+	return f
+}
+
+// Longitude : Adds a check that the value is a longitude, i.e. within [-180, 180].
+func (f *FloatRule) Longitude() *FloatRule {
+	f.addCheck(CodeLongitude, nil, func(arg float64) error {
+		if !(arg >= -180 && arg <= 180) {
+			return errFloatLongitude()
+		}
+		return nil
+	})
+	return f
+}
+
+// GEOJSON PARSING ##################################################
+
+// parseGeoObject : validates the structure of a GeoJSON object and collects its positions into the shape.
+// Features and feature collections are accepted only at the top level of the document.
+func parseGeoObject(obj map[string]interface{}, shape *geoShape, allowFeatures bool) error {
+	objType, _ := obj["type"].(string)
+	if err := parseGeoBBox(obj["bbox"]); err != nil {
+		return err
+	}
+
+	switch objType {
+	case GeoFeature:
+		if !allowFeatures {
+			return errGeoJSON("unexpected Feature")
+		}
+		return parseGeoFeature(obj, shape)
+	case GeoFeatureCollection:
+		if !allowFeatures {
+			return errGeoJSON("unexpected FeatureCollection")
+		}
+		features, ok := obj["features"].([]interface{})
+		if !ok {
+			return errGeoJSON("FeatureCollection without a features array")
+		}
+		for _, feature := range features {
+			featureObj, ok := feature.(map[string]interface{})
+			if !ok || featureObj["type"] != GeoFeature {
+				return errGeoJSON("FeatureCollection member is not a Feature")
+			}
+			if err := parseGeoFeature(featureObj, shape); err != nil {
+				return err
+			}
+		}
+		return nil
+	case GeoGeometryCollection:
+		geometries, ok := obj["geometries"].([]interface{})
+		if !ok {
+			return errGeoJSON("GeometryCollection without a geometries array")
+		}
+		for _, geometry := range geometries {
+			geometryObj, ok := geometry.(map[string]interface{})
+			if !ok {
+				return errGeoJSON("GeometryCollection member is not an object")
+			}
+			if err := parseGeoObject(geometryObj, shape, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	case GeoPoint, GeoMultiPoint, GeoLineString, GeoMultiLineString, GeoPolygon, GeoMultiPolygon:
+		return parseGeoCoordinates(objType, obj["coordinates"], shape)
+	default:
+		return errGeoJSON("unknown type")
+	}
+}
+
+// parseGeoFeature : validates a Feature, whose geometry and properties may be null.
+func parseGeoFeature(obj map[string]interface{}, shape *geoShape) error {
+	if properties := obj["properties"]; properties != nil {
+		if _, ok := properties.(map[string]interface{}); !ok {
+			return errGeoJSON("Feature properties is not an object")
+		}
+	}
+	switch obj["id"].(type) {
+	case nil, string, float64, int64, json.Number:
+	default:
+		return errGeoJSON("Feature id is neither a string nor a number")
+	}
+
+	geometry, exists := obj["geometry"]
+	if !exists {
+		return errGeoJSON("Feature without a geometry")
+	}
+	if geometry == nil {
+		return nil
+	}
+	geometryObj, ok := geometry.(map[string]interface{})
+	if !ok {
+		return errGeoJSON("Feature geometry is not an object")
+	}
+	return parseGeoObject(geometryObj, shape, false)
+}
+
+// parseGeoCoordinates : validates the coordinates of a geometry of the given type.
+func parseGeoCoordinates(objType string, coordinates interface{}, shape *geoShape) error {
+	switch objType {
+	case GeoPoint:
+		position, err := parseGeoPosition(coordinates)
+		if err != nil {
+			return err
+		}
+		shape.positions = append(shape.positions, position)
+		return nil
+	case GeoMultiPoint:
+		_, err := parseGeoPositions(coordinates, 0, shape)
+		return err
+	case GeoLineString:
+		_, err := parseGeoPositions(coordinates, 2, shape)
+		return err
+	case GeoPolygon:
+		return parseGeoPolygon(coordinates, shape)
+	}
+
+	// The remaining types are arrays of the corresponding single types.
+	members, ok := coordinates.([]interface{})
+	if !ok {
+		return errGeoJSON(objType + " coordinates is not an array")
+	}
+	for _, member := range members {
+		var err error
+		if objType == GeoMultiLineString {
+			_, err = parseGeoPositions(member, 2, shape)
+		} else {
+			err = parseGeoPolygon(member, shape)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseGeoPolygon : validates the linear rings of a polygon, which must be closed and have at least four positions.
+func parseGeoPolygon(coordinates interface{}, shape *geoShape) error {
+	rings, ok := coordinates.([]interface{})
+	if !ok || len(rings) == 0 {
+		return errGeoJSON("Polygon coordinates is not a non-empty array")
+	}
+	polygon := make([][]geoPosition, 0, len(rings))
+	for _, ring := range rings {
+		positions, err := parseGeoPositions(ring, 4, shape)
+		if err != nil {
+			return err
+		}
+		if positions[0] != positions[len(positions)-1] {
+			return errGeoJSON("Polygon ring is not closed")
+		}
+		polygon = append(polygon, positions)
+	}
+	shape.polygons = append(shape.polygons, polygon)
+	return nil
+}
+
+// parseGeoPositions : validates an array of at least minCount positions.
+func parseGeoPositions(coordinates interface{}, minCount int, shape *geoShape) ([]geoPosition, error) {
+	array, ok := coordinates.([]interface{})
+	if !ok || len(array) < minCount {
+		return nil, errGeoJSON("coordinates is not an array of enough positions")
+	}
+	positions := make([]geoPosition, 0, len(array))
+	for _, element := range array {
+		position, err := parseGeoPosition(element)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, position)
+	}
+	shape.positions = append(shape.positions, positions...)
+	return positions, nil
+}
+
+// parseGeoPosition : validates a position, which has a longitude, a latitude and an optional altitude.
+func parseGeoPosition(coordinates interface{}) (geoPosition, error) {
+	array, ok := coordinates.([]interface{})
+	if !ok || len(array) < 2 || len(array) > 3 {
+		return geoPosition{}, errGeoJSON("position is not an array of 2 or 3 numbers")
+	}
+	var numbers [3]float64
+	for i, element := range array {
+		number, ok := geoNumber(element)
+		if !ok {
+			return geoPosition{}, errGeoJSON("position is not an array of 2 or 3 numbers")
+		}
+		numbers[i] = number
+	}
+	if !(numbers[0] >= -180 && numbers[0] <= 180) || !(numbers[1] >= -90 && numbers[1] <= 90) {
+		return geoPosition{}, errGeoJSON("position is out of range")
+	}
+	return geoPosition{numbers[0], numbers[1]}, nil
+}
+
+// parseGeoBBox : validates the optional bounding box member, which has 4 or 6 numbers.
+func parseGeoBBox(bbox interface{}) error {
+	if bbox == nil {
+		return nil
+	}
+	array, ok := bbox.([]interface{})
+	if !ok || (len(array) != 4 && len(array) != 6) {
+		return errGeoJSON("bbox is not an array of 4 or 6 numbers")
+	}
+	for _, element := range array {
+		if _, ok := geoNumber(element); !ok {
+			return errGeoJSON("bbox is not an array of 4 or 6 numbers")
+		}
+	}
+	return nil
+}
+
+// geoNumber : converts a decoded JSON number to float64, including the json.Number values
+// decoded with json.Decoder.UseNumber.
+func geoNumber(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case int64:
+		return float64(number), true
+	case json.Number:
+		floatVal, err := number.Float64()
+		return floatVal, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// ringArea : the signed area of a closed ring using the shoelace formula.
+// It is positive for counterclockwise rings.
+func ringArea(ring []geoPosition) float64 {
+	area := 0.0
+	for i := 0; i < len(ring)-1; i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return area / 2
+}
+
+// isPointInRing : checks if the point lies inside the ring using ray casting.
+func isPointInRing(point geoPosition, ring []geoPosition) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a[1] > point[1]) != (b[1] > point[1]) &&
+			point[0] < (b[0]-a[0])*(point[1]-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
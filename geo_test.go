@@ -0,0 +1,114 @@
+package valkyrie
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// ccwSquare : a counterclockwise polygon around the origin.
+const ccwSquare = `{"type":"Polygon","coordinates":[[[-1,-1],[1,-1],[1,1],[-1,1],[-1,-1]]]}`
+
+// cwSquare : the same polygon as ccwSquare, wound clockwise.
+const cwSquare = `{"type":"Polygon","coordinates":[[[-1,-1],[-1,1],[1,1],[1,-1],[-1,-1]]]}`
+
+func TestGeoJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		rule *GeoJSONRule
+		doc  string
+		// code : the code of the failure, or "" if the document passes.
+		code string
+	}{
+		{"point", GeoJSON(), `{"type":"Point","coordinates":[13.4,52.5]}`, ""},
+		{"point with altitude", GeoJSON(), `{"type":"Point","coordinates":[13.4,52.5,34]}`, ""},
+		{"point out of range", GeoJSON(), `{"type":"Point","coordinates":[181,52.5]}`, CodeGeoJSON},
+		{"point of one number", GeoJSON(), `{"type":"Point","coordinates":[13.4]}`, CodeGeoJSON},
+		{"line string", GeoJSON(), `{"type":"LineString","coordinates":[[0,0],[1,1]]}`, ""},
+		{"line string of one position", GeoJSON(), `{"type":"LineString","coordinates":[[0,0]]}`, CodeGeoJSON},
+		{"polygon", GeoJSON(GeoPolygon), ccwSquare, ""},
+		{"open ring", GeoJSON(), `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1]]]}`, CodeGeoJSON},
+		{"type not allowed", GeoJSON(GeoPolygon), `{"type":"Point","coordinates":[0,0]}`, CodeGeoJSONType},
+		{"unknown type", GeoJSON(), `{"type":"Circle","coordinates":[0,0]}`, CodeGeoJSON},
+		{"feature", GeoJSON(), `{"type":"Feature","geometry":null,"properties":null}`, ""},
+		{
+			name: "feature collection",
+			rule: GeoJSON(GeoFeatureCollection),
+			doc:  `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":` + ccwSquare + `,"properties":{}}]}`,
+		},
+		{
+			name: "nested feature",
+			rule: GeoJSON(),
+			doc:  `{"type":"GeometryCollection","geometries":[{"type":"Feature","geometry":null,"properties":null}]}`,
+			code: CodeGeoJSON,
+		},
+		{"bbox", GeoJSON(), `{"type":"Point","coordinates":[0,0],"bbox":[-1,-1,1,1]}`, ""},
+		{"bad bbox", GeoJSON(), `{"type":"Point","coordinates":[0,0],"bbox":[-1,-1,1]}`, CodeGeoJSON},
+		{"not an object", GeoJSON(), `[0,0]`, CodeGeoJSON},
+
+		{"winding order", GeoJSON().WindingOrder(), ccwSquare, ""},
+		{"clockwise exterior", GeoJSON().WindingOrder(), cwSquare, CodeWindingOrder},
+		{"within bounding box", GeoJSON().WithinBoundingBox(-1, -1, 1, 1), ccwSquare, ""},
+		{"outside bounding box", GeoJSON().WithinBoundingBox(0, 0, 1, 1), ccwSquare, CodeBoundingBox},
+		{
+			name: "within polygon",
+			rule: GeoJSON().WithinPolygon([][2]float64{{-2, -2}, {2, -2}, {2, 2}, {-2, 2}}),
+			doc:  ccwSquare,
+		},
+		{
+			name: "outside polygon",
+			rule: GeoJSON().WithinPolygon([][2]float64{{0, 0}, {2, 0}, {2, 2}, {0, 2}}),
+			doc:  `{"type":"Point","coordinates":[-1,-1]}`,
+			code: CodeWithinPolygon,
+		},
+	}
+
+	for _, test := range tests {
+		for _, useNumber := range []bool{false, true} {
+			err := test.rule.Apply(decodeJSON(t, test.doc, useNumber))
+			if test.code == "" {
+				if err != nil {
+					t.Errorf("%s (UseNumber %v): Apply() = %v, want nil", test.name, useNumber, err)
+				}
+				continue
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Code != test.code {
+				t.Errorf("%s (UseNumber %v): Apply() = %#v, want code %q", test.name, useNumber, err, test.code)
+			}
+		}
+	}
+}
+
+func TestLatLng(t *testing.T) {
+	tests := []struct {
+		arg  map[string]interface{}
+		want bool
+	}{
+		{map[string]interface{}{"lat": 52.5, "lng": 13.4}, true},
+		{map[string]interface{}{"lat": -90.0, "lng": 180.0}, true},
+		{map[string]interface{}{"lat": 90.1, "lng": 13.4}, false},
+		{map[string]interface{}{"lat": 52.5, "lng": -180.1}, false},
+		{map[string]interface{}{"lat": 52.5}, false},
+	}
+
+	for _, test := range tests {
+		if got := LatLng().Apply(test.arg) == nil; got != test.want {
+			t.Errorf("LatLng().Apply(%v) passed = %v, want %v", test.arg, got, test.want)
+		}
+	}
+}
+
+// decodeJSON : decodes the document as encoding/json does, optionally with json.Decoder.UseNumber.
+func decodeJSON(t *testing.T, doc string, useNumber bool) interface{} {
+	decoder := json.NewDecoder(strings.NewReader(doc))
+	if useNumber {
+		decoder.UseNumber()
+	}
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		t.Fatalf("Decode(%q) error = %v", doc, err)
+	}
+	return decoded
+}
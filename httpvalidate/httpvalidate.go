@@ -0,0 +1,234 @@
+// Package httpvalidate provides net/http middleware that validates the path params, query, headers
+// and JSON body of requests with valkyrie rules.
+package httpvalidate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/shivanshkc/valkyrie/v2"
+)
+
+// DefaultMaxBodyBytes : The body size limit used when Options.MaxBodyBytes is zero.
+const DefaultMaxBodyBytes = 1 << 20
+
+// CodeBody : The code of the ValidationError reporting a body that is not a single JSON value.
+const CodeBody = "body"
+
+// Options : Represents the rules and limits of the middleware. Nil rules skip their part of the request.
+type Options struct {
+	// PathParams : extracts the path params of a request, usually from the router.
+	PathParams func(r *http.Request) map[string]string
+	// Path : the rule applied to the map of path params.
+	Path valkyrie.Rule
+	// Query : the rule applied to the map created by QueryMap.
+	Query valkyrie.Rule
+	// Headers : the rule applied to the map created by HeaderMap.
+	Headers valkyrie.Rule
+	// Body : the rule applied to the JSON body, decoded with json.Decoder.UseNumber.
+	// Numbers are accepted by PureInt and PureFloat, but not by PureString.
+	Body valkyrie.Rule
+	// MaxBodyBytes : the body size limit. Larger bodies are rejected with 413 Request Entity Too Large.
+	MaxBodyBytes int64
+	// OnError : writes the response when validation fails, in place of WriteProblem.
+	OnError func(w http.ResponseWriter, r *http.Request, status int, err error)
+}
+
+// Values : The parsed parts of a validated request, stored in its context.
+type Values struct {
+	Path    map[string]interface{}
+	Query   map[string]interface{}
+	Headers map[string]interface{}
+	Body    interface{}
+}
+
+type contextKey struct{}
+
+var (
+	errBodyTooLarge = func(n int64) error { return fmt.Errorf("request body should be at most %d bytes", n) }
+	errBodyInvalid  = func(err error) error { return fmt.Errorf("request body should be valid JSON: %v", err) }
+	errBodyTrailing = errors.New("request body should contain a single JSON value")
+)
+
+// Middleware : Creates a middleware that validates requests with the options before calling the handler.
+// Failures are all reported together, with a 400 Bad Request problem+json response by default.
+// The paths of the failures start with the part of the request: "path", "query", "headers" or "body".
+// Example:
+//
+//	mux.Handle("/users", httpvalidate.Middleware(httpvalidate.Options{
+//		Query: valkyrie.PureMap().Key("page", false, valkyrie.StringInt().GTE(1)),
+//		Body:  valkyrie.PureMap().Key("name", true, valkyrie.PureString().LenGTE(1)),
+//	})(usersHandler))
+func Middleware(options Options) func(http.Handler) http.Handler {
+	if options.MaxBodyBytes == 0 {
+		options.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if options.OnError == nil {
+		options.OnError = WriteProblem
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			values, status, err := validate(r, options)
+			if err != nil {
+				options.OnError(w, r, status, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, values)))
+		})
+	}
+}
+
+// FromContext : Returns the values parsed by the middleware from the context of a request.
+func FromContext(ctx context.Context) (*Values, bool) {
+	values, ok := ctx.Value(contextKey{}).(*Values)
+	return values, ok
+}
+
+// QueryMap : Converts the query into a map for a MapRule. Single values become strings
+// and repeated keys become []interface{} of strings.
+func QueryMap(query url.Values) map[string]interface{} {
+	return multiValueMap(query)
+}
+
+// HeaderMap : Converts the headers into a map for a MapRule, keyed by canonical header name
+// such as "X-Request-Id". Single values become strings and repeated headers become []interface{} of strings.
+func HeaderMap(header http.Header) map[string]interface{} {
+	return multiValueMap(header)
+}
+
+// WriteProblem : Writes the error as an application/problem+json response with the status.
+func WriteProblem(w http.ResponseWriter, r *http.Request, status int, err error) {
+	problem := valkyrie.NewProblem(err)
+	problem.Status = status
+	problem.Title = http.StatusText(status)
+	problem.Instance = r.URL.Path
+
+	w.Header().Set("Content-Type", valkyrie.ProblemContentType)
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(problem)
+}
+
+// validate : parses the parts of the request and applies their rules.
+// It also returns the status of the response for the failures.
+func validate(r *http.Request, options Options) (*Values, int, error) {
+	values := &Values{Query: QueryMap(r.URL.Query()), Headers: HeaderMap(r.Header), Path: map[string]interface{}{}}
+	if options.PathParams != nil {
+		for name, value := range options.PathParams(r) {
+			values.Path[name] = value
+		}
+	}
+
+	var errs valkyrie.MultiError
+	apply := func(source string, rule valkyrie.Rule, arg interface{}) {
+		if rule == nil {
+			return
+		}
+		if err := rule.Apply(arg); err != nil {
+			errs = append(errs, withSource(source, err)...)
+		}
+	}
+	apply("path", options.Path, values.Path)
+	apply("query", options.Query, values.Query)
+	apply("headers", options.Headers, values.Headers)
+
+	status := http.StatusBadRequest
+	if options.Body != nil {
+		body, bodyStatus, err := readBody(r, options.MaxBodyBytes)
+		if err != nil {
+			status = bodyStatus
+			errs = append(errs, &valkyrie.ValidationError{Code: CodeBody, Path: []string{"body"}, Err: err})
+		} else {
+			values.Body = body
+			apply("body", options.Body, body)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, status, errs
+	}
+	return values, 0, nil
+}
+
+// withSource : splits the failures of a rule and prefixes their paths with the part of the request,
+// so that a query param and a body key of the same name are told apart.
+// Errors other than ValidationErrors get the CodeCustom code.
+func withSource(source string, err error) valkyrie.MultiError {
+	errs, ok := err.(valkyrie.MultiError)
+	if !ok {
+		errs = valkyrie.MultiError{err}
+	}
+
+	prefixed := make(valkyrie.MultiError, 0, len(errs))
+	for _, err := range errs {
+		if multi, ok := err.(valkyrie.MultiError); ok {
+			prefixed = append(prefixed, withSource(source, multi)...)
+			continue
+		}
+		validationErr, ok := err.(*valkyrie.ValidationError)
+		if !ok {
+			validationErr = &valkyrie.ValidationError{Code: valkyrie.CodeCustom, Err: err}
+		}
+		copied := *validationErr
+		copied.Path = append([]string{source}, validationErr.Path...)
+		prefixed = append(prefixed, &copied)
+	}
+	return prefixed
+}
+
+// readBody : decodes the JSON body of the request within the size limit and restores it for the handler.
+// An empty body is decoded as nil.
+func readBody(r *http.Request, maxBytes int64) (interface{}, int, error) {
+	if r.Body == nil {
+		return nil, 0, nil
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+	_ = r.Body.Close()
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, http.StatusRequestEntityTooLarge, errBodyTooLarge(maxBytes)
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(data))
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, 0, nil
+	}
+
+	var body interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return nil, http.StatusBadRequest, errBodyInvalid(err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, http.StatusBadRequest, errBodyTrailing
+	}
+	return body, 0, nil
+}
+
+// multiValueMap : converts the values of a url.Values or an http.Header into a map for a MapRule.
+func multiValueMap(values map[string][]string) map[string]interface{} {
+	converted := make(map[string]interface{}, len(values))
+	for key, list := range values {
+		if len(list) == 1 {
+			converted[key] = list[0]
+			continue
+		}
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		converted[key] = items
+	}
+	return converted
+}
@@ -0,0 +1,152 @@
+package httpvalidate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shivanshkc/valkyrie/v2"
+)
+
+func TestMiddleware(t *testing.T) {
+	options := Options{
+		PathParams: func(r *http.Request) map[string]string {
+			return map[string]string{"id": strings.TrimPrefix(r.URL.Path, "/users/")}
+		},
+		Path:    valkyrie.PureMap().Key("id", true, valkyrie.StringInt().GTE(1)),
+		Query:   valkyrie.PureMap().Key("page", false, valkyrie.StringInt().GTE(1)),
+		Headers: valkyrie.PureMap().Key("X-Request-Id", true, valkyrie.PureString().LenGTE(1)),
+		Body: valkyrie.PureMap().AllErrors().
+			Key("name", true, valkyrie.PureString().LenGTE(1)).
+			Key("age", false, valkyrie.PureInt().GTE(0)),
+		MaxBodyBytes: 64,
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		header bool
+		body   string
+		status int
+		// params : the names and the codes of the invalid params.
+		params [][2]string
+	}{
+		{name: "valid", target: "/users/7?page=2", header: true, body: `{"name":"a","age":30}`, status: http.StatusOK},
+		{
+			name:   "all parts invalid",
+			target: "/users/0?page=0",
+			body:   `{"age":-1}`,
+			status: http.StatusBadRequest,
+			params: [][2]string{
+				{"path.id", valkyrie.CodeGTE},
+				{"query.page", valkyrie.CodeGTE},
+				{"headers.X-Request-Id", valkyrie.CodeRequired},
+				{"body.name", valkyrie.CodeRequired},
+				{"body.age", valkyrie.CodeGTE},
+			},
+		},
+		{
+			name:   "repeated query param",
+			target: "/users/7?page=1&page=2",
+			header: true,
+			body:   `{"name":"a"}`,
+			status: http.StatusBadRequest,
+			params: [][2]string{{"query.page", valkyrie.CodeType}},
+		},
+		{
+			name:   "malformed body along with an invalid query",
+			target: "/users/7?page=0",
+			header: true,
+			body:   `{"name":`,
+			status: http.StatusBadRequest,
+			params: [][2]string{{"query.page", valkyrie.CodeGTE}, {"body", CodeBody}},
+		},
+		{
+			name:   "trailing data",
+			target: "/users/7",
+			header: true,
+			body:   `{"name":"a"} {}`,
+			status: http.StatusBadRequest,
+			params: [][2]string{{"body", CodeBody}},
+		},
+		{
+			name:   "body too large",
+			target: "/users/7",
+			header: true,
+			body:   `{"name":"` + strings.Repeat("a", 64) + `"}`,
+			status: http.StatusRequestEntityTooLarge,
+			params: [][2]string{{"body", CodeBody}},
+		},
+		{
+			name:   "empty body",
+			target: "/users/7",
+			header: true,
+			status: http.StatusBadRequest,
+			params: [][2]string{{"body", valkyrie.CodeType}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var body []byte
+			handler := Middleware(options)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				values, ok := FromContext(r.Context())
+				if !ok || values.Body == nil {
+					t.Errorf("FromContext() = %+v, %v, want the parsed values", values, ok)
+				}
+				body, _ = ioutil.ReadAll(r.Body)
+			}))
+
+			r := httptest.NewRequest(http.MethodPost, test.target, strings.NewReader(test.body))
+			if test.header {
+				r.Header.Set("x-request-id", "abc")
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != test.status {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, test.status, w.Body)
+			}
+			if test.status == http.StatusOK {
+				if string(body) != test.body {
+					t.Errorf("handler body = %q, want the restored %q", body, test.body)
+				}
+				return
+			}
+
+			if contentType := w.Header().Get("Content-Type"); contentType != valkyrie.ProblemContentType {
+				t.Errorf("Content-Type = %q, want %q", contentType, valkyrie.ProblemContentType)
+			}
+			var problem valkyrie.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			var params [][2]string
+			for _, param := range problem.InvalidParams {
+				params = append(params, [2]string{param.Name, param.Code})
+			}
+			if !reflect.DeepEqual(params, test.params) {
+				t.Errorf("invalid params = %v, want %v", params, test.params)
+			}
+			if problem.Status != test.status || problem.Instance != r.URL.Path {
+				t.Errorf("problem status = %d, instance = %q", problem.Status, problem.Instance)
+			}
+		})
+	}
+}
+
+func TestMultiValueMaps(t *testing.T) {
+	query := QueryMap(map[string][]string{"a": {"1"}, "b": {"1", "2"}})
+	if want := map[string]interface{}{"a": "1", "b": []interface{}{"1", "2"}}; !reflect.DeepEqual(query, want) {
+		t.Errorf("QueryMap() = %v, want %v", query, want)
+	}
+	header := http.Header{}
+	header.Add("content-type", "text/plain")
+	if got := HeaderMap(header); got["Content-Type"] != "text/plain" {
+		t.Errorf("HeaderMap() = %v, want the canonical header name", got)
+	}
+}
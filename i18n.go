@@ -0,0 +1,305 @@
+package valkyrie
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Translator : Represents a source of localized message templates, keyed by the stable error codes.
+type Translator interface {
+	// Translate : Returns the message template for the code in the given locale, with the plural form
+	// chosen from the params. It reports false if there is no template.
+	Translate(locale string, code string, params Params) (string, bool)
+}
+
+// Message : Represents a message template of a catalog along with its plural forms.
+// In JSON, it is either a plain template or an object such as
+// {"plural": "min", "one": "{field} needs {min} character", "other": "{field} needs {min} characters"}.
+type Message struct {
+	// Plural : the name of the numeric param that selects the plural form, if any.
+	Plural string
+	// Forms : the templates keyed by CLDR plural category: zero, one, two, few, many or other.
+	Forms map[string]string
+}
+
+// Catalog : Represents the message templates of a locale, keyed by the stable error codes.
+type Catalog map[string]Message
+
+// PluralRule : Represents a function that returns the CLDR plural category of a number,
+// such as "one" or "other".
+type PluralRule func(n float64) string
+
+// Bundle : A Translator holding the catalogs of several locales.
+// Locales such as "de-AT" fall back to their language, "de".
+type Bundle struct {
+	catalogs map[string]Catalog
+	mutex    sync.RWMutex
+}
+
+// DefaultBundle : The bundle used by ApplyContext when the context holds no translator.
+// It contains the bundled catalogs and accepts additional ones.
+var DefaultBundle = NewBundle()
+
+var (
+	// pluralRules : the plural rules, keyed by language.
+	pluralRules = map[string]PluralRule{
+		"fr": pluralFrench,
+		"ja": pluralNone, "ko": pluralNone, "zh": pluralNone,
+		"pl": pluralPolish,
+		"ru": pluralSlavic, "uk": pluralSlavic,
+	}
+	// pluralRulesMutex : guards the plural rules.
+	pluralRulesMutex sync.RWMutex
+)
+
+type contextKey int
+
+const (
+	localeContextKey contextKey = iota
+	translatorContextKey
+)
+
+// UnmarshalJSON : Decodes a message from either a plain template or an object of plural forms.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var template string
+	if err := json.Unmarshal(data, &template); err == nil {
+		*m = Message{Forms: map[string]string{"other": template}}
+		return nil
+	}
+
+	var forms map[string]string
+	if err := json.Unmarshal(data, &forms); err != nil {
+		return err
+	}
+	*m = Message{Plural: forms["plural"], Forms: forms}
+	delete(forms, "plural")
+	return nil
+}
+
+// NewBundle : Creates a bundle containing the bundled catalogs: English (en), German (de) and Japanese (ja).
+func NewBundle() *Bundle {
+	bundle := &Bundle{catalogs: map[string]Catalog{}}
+	for locale, catalog := range bundledCatalogs {
+		bundle.AddCatalog(locale, catalog)
+	}
+	return bundle
+}
+
+// ReadCatalog : Creates a catalog from a reader with a JSON object of message templates keyed by code.
+// Example: {"gte": "{field} muss mindestens {min} sein", "required": "{field} ist erforderlich"}
+func ReadCatalog(reader io.Reader) (Catalog, error) {
+	catalog := Catalog{}
+	if err := json.NewDecoder(reader).Decode(&catalog); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// LoadCatalog : Creates a catalog from a local JSON file, in the format of ReadCatalog.
+func LoadCatalog(path string) (Catalog, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadCatalog(file)
+}
+
+// AddCatalog : Adds the templates of the catalog to the locale, replacing those with the same codes.
+func (b *Bundle) AddCatalog(locale string, catalog Catalog) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	locale = normalizeLocale(locale)
+	existing, exists := b.catalogs[locale]
+	if !exists {
+		existing = Catalog{}
+		b.catalogs[locale] = existing
+	}
+	for code, message := range catalog {
+		existing[code] = message
+	}
+}
+
+// Translate : Returns the message template for the code in the given locale, or in its language.
+func (b *Bundle) Translate(locale string, code string, params Params) (string, bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	locale = normalizeLocale(locale)
+	language := locale
+	if index := strings.IndexByte(locale, '-'); index >= 0 {
+		language = locale[:index]
+	}
+
+	message, exists := b.catalogs[locale][code]
+	if !exists {
+		message, exists = b.catalogs[language][code]
+	}
+	if !exists {
+		return "", false
+	}
+
+	if n, ok := paramNumber(params[message.Plural]); ok {
+		if template, exists := message.Forms[pluralRule(language)(n)]; exists {
+			return template, true
+		}
+	}
+	template, exists := message.Forms["other"]
+	return template, exists
+}
+
+// RegisterPluralRule : Registers the plural rule of a language, such as "cs".
+// Languages without a rule use the English one: "one" for 1 and "other" for the rest.
+func RegisterPluralRule(language string, rule PluralRule) {
+	pluralRulesMutex.Lock()
+	defer pluralRulesMutex.Unlock()
+	pluralRules[strings.ToLower(language)] = rule
+}
+
+// Localize : Replaces the messages of the validation errors with the templates of the translator
+// for the locale. Errors with a message set by Msg, and errors without a template, are left as they are.
+// A ValidationError wrapping a MultiError, such as the failure of Password, keeps its message and has
+// the wrapped errors localized instead, so each of the reasons is kept.
+func Localize(err error, translator Translator, locale string) error {
+	switch typed := err.(type) {
+	case *ValidationError:
+		if typed.Message != "" {
+			return err
+		}
+		if multi, ok := typed.Err.(MultiError); ok {
+			localized := *typed
+			localized.Err = Localize(multi, translator, locale)
+			return &localized
+		}
+		template, ok := translator.Translate(locale, typed.Code, typed.Params)
+		if !ok {
+			return err
+		}
+		localized := *typed
+		localized.Message = template
+		return &localized
+	case MultiError:
+		localized := make(MultiError, len(typed))
+		for i, err := range typed {
+			localized[i] = Localize(err, translator, locale)
+		}
+		return localized
+	}
+	return err
+}
+
+// WithLocale : Returns a copy of the context that selects the locale for ApplyContext.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey, locale)
+}
+
+// LocaleFromContext : Returns the locale selected by WithLocale, or an empty string.
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeContextKey).(string)
+	return locale
+}
+
+// WithTranslator : Returns a copy of the context that selects the translator for ApplyContext,
+// in place of the DefaultBundle.
+func WithTranslator(ctx context.Context, translator Translator) context.Context {
+	return context.WithValue(ctx, translatorContextKey, translator)
+}
+
+// ApplyContext : Applies the rule on the argument and localizes the error into the locale of the context.
+// Without a locale, the error is returned as it is.
+func ApplyContext(ctx context.Context, rule Rule, arg interface{}) error {
+	err := rule.Apply(arg)
+	if err == nil {
+		return nil
+	}
+	locale := LocaleFromContext(ctx)
+	if locale == "" {
+		return err
+	}
+	translator, ok := ctx.Value(translatorContextKey).(Translator)
+	if !ok {
+		translator = DefaultBundle
+	}
+	return Localize(err, translator, locale)
+}
+
+// normalizeLocale : lowercases the locale and uses hyphens as separators, so "de_AT" becomes "de-at".
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.Replace(locale, "_", "-", -1))
+}
+
+// pluralRule : returns the plural rule of the language.
+func pluralRule(language string) PluralRule {
+	pluralRulesMutex.RLock()
+	defer pluralRulesMutex.RUnlock()
+	if rule, exists := pluralRules[language]; exists {
+		return rule
+	}
+	return pluralEnglish
+}
+
+// paramNumber : converts a numeric param into a float64.
+func paramNumber(param interface{}) (float64, bool) {
+	value := reflect.ValueOf(param)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	}
+	return 0, false
+}
+
+func pluralEnglish(n float64) string {
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+func pluralFrench(n float64) string {
+	if n >= 0 && n < 2 {
+		return "one"
+	}
+	return "other"
+}
+
+func pluralNone(float64) string {
+	return "other"
+}
+
+func pluralSlavic(n float64) string {
+	if n != math.Trunc(n) {
+		return "other"
+	}
+	mod10, mod100 := math.Mod(n, 10), math.Mod(n, 100)
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return "one"
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return "few"
+	}
+	return "many"
+}
+
+func pluralPolish(n float64) string {
+	if n == 1 {
+		return "one"
+	}
+	if category := pluralSlavic(n); category == "few" {
+		return category
+	}
+	if n != math.Trunc(n) {
+		return "other"
+	}
+	return "many"
+}
@@ -0,0 +1,249 @@
+package valkyrie
+
+// bundledCatalogs : the catalogs contained in every bundle created by NewBundle.
+var bundledCatalogs = map[string]Catalog{
+	"en": {
+		CodeInternal:         catalogText("{field} could not be validated due to an internal error"),
+		CodeType:             catalogText("{field} must be of type {type}"),
+		CodeNotNull:          catalogText("{field} must not be null"),
+		CodeRequired:         catalogText("{field} is required"),
+		CodeBlind:            catalogText("{field} is not allowed"),
+		CodeExcept:           catalogText("{field} must not be {value}"),
+		CodeGTE:              catalogText("{field} must be at least {min}"),
+		CodeLTE:              catalogText("{field} must be at most {max}"),
+		CodeGT:               catalogText("{field} must be greater than {min}"),
+		CodeLT:               catalogText("{field} must be less than {max}"),
+		CodeIsTrue:           catalogText("{field} must be true"),
+		CodeIsFalse:          catalogText("{field} must be false"),
+		CodeLatitude:         catalogText("{field} must be a valid latitude"),
+		CodeLongitude:        catalogText("{field} must be a valid longitude"),
+		CodeLenGTE:           catalogPlural("min", "{field} must be at least {min} character long", "{field} must be at least {min} characters long"),
+		CodeLenLTE:           catalogPlural("max", "{field} must be at most {max} character long", "{field} must be at most {max} characters long"),
+		CodeLenGT:            catalogPlural("min", "{field} must be longer than {min} character", "{field} must be longer than {min} characters"),
+		CodeLenLT:            catalogPlural("max", "{field} must be shorter than {max} character", "{field} must be shorter than {max} characters"),
+		CodePattern:          catalogText("{field} must match the pattern {pattern}"),
+		CodeUUID:             catalogText("{field} must be a valid UUID"),
+		CodeBase64:           catalogText("{field} must be valid base64"),
+		CodeHex:              catalogText("{field} must be valid hexadecimal"),
+		CodeJSON:             catalogText("{field} must be valid JSON"),
+		CodeCreditCard:       catalogText("{field} must be a valid card number"),
+		CodeIBAN:             catalogText("{field} must be a valid IBAN"),
+		CodeBIC:              catalogText("{field} must be a valid BIC"),
+		CodeISBN10:           catalogText("{field} must be a valid ISBN-10"),
+		CodeISBN13:           catalogText("{field} must be a valid ISBN-13"),
+		CodeEAN13:            catalogText("{field} must be a valid EAN-13"),
+		CodeCountryCode:      catalogText("{field} must be a valid country code"),
+		CodeCurrencyCode:     catalogText("{field} must be a valid currency code"),
+		CodeLanguageTag:      catalogText("{field} must be a valid language tag"),
+		CodeTimeZone:         catalogText("{field} must be a valid time zone"),
+		CodeE164:             catalogText("{field} must be a phone number in E.164 format"),
+		CodePhoneNumber:      catalogText("{field} must be a valid phone number"),
+		CodePostalCode:       catalogText("{field} must be a valid postal code for {country}"),
+		CodeSemVer:           catalogText("{field} must be a valid semantic version"),
+		CodeSemVerCompare:    catalogText("{field} must be a version {op} {version}"),
+		CodeSemVerRange:      catalogText("{field} must be a valid version range"),
+		CodeSemVerSatisfies:  catalogText("{field} must be a version satisfying {range}"),
+		CodeCron:             catalogText("{field} must be a valid cron expression"),
+		CodeCronInterval:     catalogText("{field} must not fire more often than every {interval}"),
+		CodeJWT:              catalogText("{field} must be a valid JWT"),
+		CodePassword:         catalogText("{field} does not meet the password requirements"),
+		CodeContainsAnyOf:    catalogText("{field} must contain one of the listed terms"),
+		CodeNotContainsAnyOf: catalogText("{field} must not contain forbidden terms"),
+		CodeNoSecrets:        catalogText("{field} must not contain secrets"),
+		CodeNoPII:            catalogText("{field} must not contain personal data"),
+		CodeItemsGTE:         catalogPlural("min", "{field} must have at least {min} item", "{field} must have at least {min} items"),
+		CodeItemsLTE:         catalogPlural("max", "{field} must have at most {max} item", "{field} must have at most {max} items"),
+		CodeUnique:           catalogText("{field} must not contain duplicates"),
+		CodeForm:             catalogText("{field} must be a valid form"),
+		CodeFileMaxSize:      catalogPlural("max", "{field} must be at most {max} byte", "{field} must be at most {max} bytes"),
+		CodeFileMinSize:      catalogPlural("min", "{field} must be at least {min} byte", "{field} must be at least {min} bytes"),
+		CodeFileType:         catalogText("{field} must be a file of type {types}"),
+		CodeFileExtension:    catalogText("{field} must have one of the extensions {extensions}"),
+		CodeFileName:         catalogText("{field} must have a safe file name"),
+		CodeImageMinSize:     catalogText("{field} must be an image of at least {width}x{height} pixels"),
+		CodeImageMaxSize:     catalogText("{field} must be an image of at most {width}x{height} pixels"),
+		CodeGeoJSON:          catalogText("{field} must be valid GeoJSON"),
+		CodeGeoJSONType:      catalogText("{field} must be GeoJSON of type {types}"),
+		CodeWindingOrder:     catalogText("{field} must follow the right-hand rule"),
+		CodeBoundingBox:      catalogText("{field} must lie within the bounding box"),
+		CodeWithinPolygon:    catalogText("{field} must lie within the polygon"),
+
+		CodePasswordMinLength:  catalogPlural("min", "password must have at least {min} character", "password must have at least {min} characters"),
+		CodePasswordMaxLength:  catalogPlural("max", "password must have at most {max} character", "password must have at most {max} characters"),
+		CodePasswordUpper:      catalogPlural("min", "password must have at least {min} uppercase letter", "password must have at least {min} uppercase letters"),
+		CodePasswordLower:      catalogPlural("min", "password must have at least {min} lowercase letter", "password must have at least {min} lowercase letters"),
+		CodePasswordDigits:     catalogPlural("min", "password must have at least {min} digit", "password must have at least {min} digits"),
+		CodePasswordSymbols:    catalogPlural("min", "password must have at least {min} symbol", "password must have at least {min} symbols"),
+		CodePasswordEntropy:    catalogText("password must have an entropy of at least {bits} bits"),
+		CodePasswordRepeated:   catalogText("password must not repeat a character more than {max} times"),
+		CodePasswordSequential: catalogText("password must not have sequences longer than {max} characters"),
+		CodePasswordUsername:   catalogText("password must not contain the username"),
+		CodePasswordBlocked:    catalogText("password must not be a commonly used password"),
+	},
+	"de": {
+		CodeInternal:         catalogText("{field} konnte wegen eines internen Fehlers nicht geprüft werden"),
+		CodeType:             catalogText("{field} muss vom Typ {type} sein"),
+		CodeNotNull:          catalogText("{field} darf nicht null sein"),
+		CodeRequired:         catalogText("{field} ist erforderlich"),
+		CodeBlind:            catalogText("{field} ist nicht erlaubt"),
+		CodeExcept:           catalogText("{field} darf nicht {value} sein"),
+		CodeGTE:              catalogText("{field} muss mindestens {min} sein"),
+		CodeLTE:              catalogText("{field} darf höchstens {max} sein"),
+		CodeGT:               catalogText("{field} muss größer als {min} sein"),
+		CodeLT:               catalogText("{field} muss kleiner als {max} sein"),
+		CodeIsTrue:           catalogText("{field} muss wahr sein"),
+		CodeIsFalse:          catalogText("{field} muss falsch sein"),
+		CodeLatitude:         catalogText("{field} muss ein gültiger Breitengrad sein"),
+		CodeLongitude:        catalogText("{field} muss ein gültiger Längengrad sein"),
+		CodeLenGTE:           catalogText("{field} muss mindestens {min} Zeichen lang sein"),
+		CodeLenLTE:           catalogText("{field} darf höchstens {max} Zeichen lang sein"),
+		CodeLenGT:            catalogText("{field} muss länger als {min} Zeichen sein"),
+		CodeLenLT:            catalogText("{field} muss kürzer als {max} Zeichen sein"),
+		CodePattern:          catalogText("{field} muss dem Muster {pattern} entsprechen"),
+		CodeUUID:             catalogText("{field} muss eine gültige UUID sein"),
+		CodeBase64:           catalogText("{field} muss gültiges Base64 sein"),
+		CodeHex:              catalogText("{field} muss eine gültige Hexadezimalzeichenfolge sein"),
+		CodeJSON:             catalogText("{field} muss gültiges JSON sein"),
+		CodeCreditCard:       catalogText("{field} muss eine gültige Kartennummer sein"),
+		CodeIBAN:             catalogText("{field} muss eine gültige IBAN sein"),
+		CodeBIC:              catalogText("{field} muss eine gültige BIC sein"),
+		CodeISBN10:           catalogText("{field} muss eine gültige ISBN-10 sein"),
+		CodeISBN13:           catalogText("{field} muss eine gültige ISBN-13 sein"),
+		CodeEAN13:            catalogText("{field} muss eine gültige EAN-13 sein"),
+		CodeCountryCode:      catalogText("{field} muss ein gültiger Ländercode sein"),
+		CodeCurrencyCode:     catalogText("{field} muss ein gültiger Währungscode sein"),
+		CodeLanguageTag:      catalogText("{field} muss ein gültiges Sprach-Tag sein"),
+		CodeTimeZone:         catalogText("{field} muss eine gültige Zeitzone sein"),
+		CodeE164:             catalogText("{field} muss eine Telefonnummer im E.164-Format sein"),
+		CodePhoneNumber:      catalogText("{field} muss eine gültige Telefonnummer sein"),
+		CodePostalCode:       catalogText("{field} muss eine gültige Postleitzahl für {country} sein"),
+		CodeSemVer:           catalogText("{field} muss eine gültige semantische Version sein"),
+		CodeSemVerCompare:    catalogText("{field} muss eine Version {op} {version} sein"),
+		CodeSemVerRange:      catalogText("{field} muss ein gültiger Versionsbereich sein"),
+		CodeSemVerSatisfies:  catalogText("{field} muss eine Version sein, die {range} erfüllt"),
+		CodeCron:             catalogText("{field} muss ein gültiger Cron-Ausdruck sein"),
+		CodeCronInterval:     catalogText("{field} darf höchstens alle {interval} ausgelöst werden"),
+		CodeJWT:              catalogText("{field} muss ein gültiges JWT sein"),
+		CodePassword:         catalogText("{field} erfüllt die Passwortanforderungen nicht"),
+		CodeContainsAnyOf:    catalogText("{field} muss einen der aufgeführten Begriffe enthalten"),
+		CodeNotContainsAnyOf: catalogText("{field} darf keine verbotenen Begriffe enthalten"),
+		CodeNoSecrets:        catalogText("{field} darf keine Zugangsdaten oder Schlüssel enthalten"),
+		CodeNoPII:            catalogText("{field} darf keine personenbezogenen Daten enthalten"),
+		CodeItemsGTE:         catalogPlural("min", "{field} muss mindestens {min} Element enthalten", "{field} muss mindestens {min} Elemente enthalten"),
+		CodeItemsLTE:         catalogPlural("max", "{field} darf höchstens {max} Element enthalten", "{field} darf höchstens {max} Elemente enthalten"),
+		CodeUnique:           catalogText("{field} darf keine Duplikate enthalten"),
+		CodeForm:             catalogText("{field} muss ein gültiges Formular sein"),
+		CodeFileMaxSize:      catalogPlural("max", "{field} darf höchstens {max} Byte groß sein", "{field} darf höchstens {max} Bytes groß sein"),
+		CodeFileMinSize:      catalogPlural("min", "{field} muss mindestens {min} Byte groß sein", "{field} muss mindestens {min} Bytes groß sein"),
+		CodeFileType:         catalogText("{field} muss eine Datei vom Typ {types} sein"),
+		CodeFileExtension:    catalogText("{field} muss eine der Endungen {extensions} haben"),
+		CodeFileName:         catalogText("{field} muss einen sicheren Dateinamen haben"),
+		CodeImageMinSize:     catalogText("{field} muss ein Bild mit mindestens {width}x{height} Pixeln sein"),
+		CodeImageMaxSize:     catalogText("{field} muss ein Bild mit höchstens {width}x{height} Pixeln sein"),
+		CodeGeoJSON:          catalogText("{field} muss gültiges GeoJSON sein"),
+		CodeGeoJSONType:      catalogText("{field} muss GeoJSON vom Typ {types} sein"),
+		CodeWindingOrder:     catalogText("{field} muss der Rechte-Hand-Regel folgen"),
+		CodeBoundingBox:      catalogText("{field} muss innerhalb des Begrenzungsrahmens liegen"),
+		CodeWithinPolygon:    catalogText("{field} muss innerhalb des Polygons liegen"),
+
+		CodePasswordMinLength:  catalogText("Passwort muss mindestens {min} Zeichen haben"),
+		CodePasswordMaxLength:  catalogText("Passwort darf höchstens {max} Zeichen haben"),
+		CodePasswordUpper:      catalogText("Passwort muss mindestens {min} Großbuchstaben enthalten"),
+		CodePasswordLower:      catalogText("Passwort muss mindestens {min} Kleinbuchstaben enthalten"),
+		CodePasswordDigits:     catalogPlural("min", "Passwort muss mindestens {min} Ziffer enthalten", "Passwort muss mindestens {min} Ziffern enthalten"),
+		CodePasswordSymbols:    catalogText("Passwort muss mindestens {min} Sonderzeichen enthalten"),
+		CodePasswordEntropy:    catalogText("Passwort muss eine Entropie von mindestens {bits} Bit haben"),
+		CodePasswordRepeated:   catalogText("Passwort darf ein Zeichen nicht mehr als {max}-mal wiederholen"),
+		CodePasswordSequential: catalogText("Passwort darf keine Folgen von mehr als {max} Zeichen enthalten"),
+		CodePasswordUsername:   catalogText("Passwort darf den Benutzernamen nicht enthalten"),
+		CodePasswordBlocked:    catalogText("Passwort darf kein häufig verwendetes Passwort sein"),
+	},
+	"ja": {
+		CodeInternal:         catalogText("内部エラーのため{field}を検証できませんでした"),
+		CodeType:             catalogText("{field}は{type}型である必要があります"),
+		CodeNotNull:          catalogText("{field}はnullにできません"),
+		CodeRequired:         catalogText("{field}は必須です"),
+		CodeBlind:            catalogText("{field}は許可されていません"),
+		CodeExcept:           catalogText("{field}に{value}は使用できません"),
+		CodeGTE:              catalogText("{field}は{min}以上である必要があります"),
+		CodeLTE:              catalogText("{field}は{max}以下である必要があります"),
+		CodeGT:               catalogText("{field}は{min}より大きい必要があります"),
+		CodeLT:               catalogText("{field}は{max}未満である必要があります"),
+		CodeIsTrue:           catalogText("{field}はtrueである必要があります"),
+		CodeIsFalse:          catalogText("{field}はfalseである必要があります"),
+		CodeLatitude:         catalogText("{field}は有効な緯度である必要があります"),
+		CodeLongitude:        catalogText("{field}は有効な経度である必要があります"),
+		CodeLenGTE:           catalogText("{field}は{min}文字以上である必要があります"),
+		CodeLenLTE:           catalogText("{field}は{max}文字以下である必要があります"),
+		CodeLenGT:            catalogText("{field}は{min}文字より長い必要があります"),
+		CodeLenLT:            catalogText("{field}は{max}文字未満である必要があります"),
+		CodePattern:          catalogText("{field}はパターン{pattern}に一致する必要があります"),
+		CodeUUID:             catalogText("{field}は有効なUUIDである必要があります"),
+		CodeBase64:           catalogText("{field}は有効なBase64である必要があります"),
+		CodeHex:              catalogText("{field}は有効な16進数文字列である必要があります"),
+		CodeJSON:             catalogText("{field}は有効なJSONである必要があります"),
+		CodeCreditCard:       catalogText("{field}は有効なカード番号である必要があります"),
+		CodeIBAN:             catalogText("{field}は有効なIBANである必要があります"),
+		CodeBIC:              catalogText("{field}は有効なBICである必要があります"),
+		CodeISBN10:           catalogText("{field}は有効なISBN-10である必要があります"),
+		CodeISBN13:           catalogText("{field}は有効なISBN-13である必要があります"),
+		CodeEAN13:            catalogText("{field}は有効なEAN-13である必要があります"),
+		CodeCountryCode:      catalogText("{field}は有効な国コードである必要があります"),
+		CodeCurrencyCode:     catalogText("{field}は有効な通貨コードである必要があります"),
+		CodeLanguageTag:      catalogText("{field}は有効な言語タグである必要があります"),
+		CodeTimeZone:         catalogText("{field}は有効なタイムゾーンである必要があります"),
+		CodeE164:             catalogText("{field}はE.164形式の電話番号である必要があります"),
+		CodePhoneNumber:      catalogText("{field}は有効な電話番号である必要があります"),
+		CodePostalCode:       catalogText("{field}は{country}の有効な郵便番号である必要があります"),
+		CodeSemVer:           catalogText("{field}は有効なセマンティックバージョンである必要があります"),
+		CodeSemVerCompare:    catalogText("{field}は{op} {version}を満たすバージョンである必要があります"),
+		CodeSemVerRange:      catalogText("{field}は有効なバージョン範囲である必要があります"),
+		CodeSemVerSatisfies:  catalogText("{field}は{range}を満たすバージョンである必要があります"),
+		CodeCron:             catalogText("{field}は有効なcron式である必要があります"),
+		CodeCronInterval:     catalogText("{field}の実行間隔は{interval}以上である必要があります"),
+		CodeJWT:              catalogText("{field}は有効なJWTである必要があります"),
+		CodePassword:         catalogText("{field}はパスワードの要件を満たしていません"),
+		CodeContainsAnyOf:    catalogText("{field}には指定された語句のいずれかを含める必要があります"),
+		CodeNotContainsAnyOf: catalogText("{field}に禁止されている語句を含めることはできません"),
+		CodeNoSecrets:        catalogText("{field}に秘密情報を含めることはできません"),
+		CodeNoPII:            catalogText("{field}に個人情報を含めることはできません"),
+		CodeItemsGTE:         catalogText("{field}には{min}個以上の要素が必要です"),
+		CodeItemsLTE:         catalogText("{field}の要素は{max}個以下である必要があります"),
+		CodeUnique:           catalogText("{field}に重複を含めることはできません"),
+		CodeForm:             catalogText("{field}は有効なフォームである必要があります"),
+		CodeFileMaxSize:      catalogText("{field}は{max}バイト以下である必要があります"),
+		CodeFileMinSize:      catalogText("{field}は{min}バイト以上である必要があります"),
+		CodeFileType:         catalogText("{field}は{types}形式のファイルである必要があります"),
+		CodeFileExtension:    catalogText("{field}の拡張子は{extensions}のいずれかである必要があります"),
+		CodeFileName:         catalogText("{field}は安全なファイル名である必要があります"),
+		CodeImageMinSize:     catalogText("{field}は{width}x{height}ピクセル以上の画像である必要があります"),
+		CodeImageMaxSize:     catalogText("{field}は{width}x{height}ピクセル以下の画像である必要があります"),
+		CodeGeoJSON:          catalogText("{field}は有効なGeoJSONである必要があります"),
+		CodeGeoJSONType:      catalogText("{field}は{types}型のGeoJSONである必要があります"),
+		CodeWindingOrder:     catalogText("{field}は右手の法則に従う必要があります"),
+		CodeBoundingBox:      catalogText("{field}は境界ボックス内にある必要があります"),
+		CodeWithinPolygon:    catalogText("{field}はポリゴン内にある必要があります"),
+
+		CodePasswordMinLength:  catalogText("パスワードは{min}文字以上である必要があります"),
+		CodePasswordMaxLength:  catalogText("パスワードは{max}文字以下である必要があります"),
+		CodePasswordUpper:      catalogText("パスワードには大文字が{min}文字以上必要です"),
+		CodePasswordLower:      catalogText("パスワードには小文字が{min}文字以上必要です"),
+		CodePasswordDigits:     catalogText("パスワードには数字が{min}文字以上必要です"),
+		CodePasswordSymbols:    catalogText("パスワードには記号が{min}文字以上必要です"),
+		CodePasswordEntropy:    catalogText("パスワードのエントロピーは{bits}ビット以上である必要があります"),
+		CodePasswordRepeated:   catalogText("パスワードで同じ文字を{max}回より多く繰り返すことはできません"),
+		CodePasswordSequential: catalogText("パスワードに{max}文字より長い連続した文字列を含めることはできません"),
+		CodePasswordUsername:   catalogText("パスワードにユーザー名を含めることはできません"),
+		CodePasswordBlocked:    catalogText("パスワードによく使われるパスワードは使用できません"),
+	},
+}
+
+// catalogText : creates a message without plural forms.
+func catalogText(template string) Message {
+	return Message{Forms: map[string]string{"other": template}}
+}
+
+// catalogPlural : creates a message with the "one" and "other" plural forms, selected by the param.
+func catalogPlural(param string, one string, other string) Message {
+	return Message{Plural: param, Forms: map[string]string{"one": one, "other": other}}
+}
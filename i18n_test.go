@@ -0,0 +1,133 @@
+package valkyrie
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestApplyContext(t *testing.T) {
+	polish, err := ReadCatalog(strings.NewReader(`{
+		"len_gte": {"plural": "min", "one": "{field}: {min} znak", "few": "{field}: {min} znaki", "many": "{field}: {min} znaków"},
+		"required": "{field} jest wymagane"
+	}`))
+	if err != nil {
+		t.Fatalf("ReadCatalog() error = %v", err)
+	}
+	bundle := NewBundle()
+	bundle.AddCatalog("pl", polish)
+
+	name := func(rule Rule) Rule { return PureMap().Key("name", true, rule) }
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		rule   Rule
+		arg    interface{}
+		want   string
+		reason string
+	}{
+		{
+			name: "english singular",
+			ctx:  WithLocale(context.Background(), "en"),
+			rule: name(PureString().LenGTE(1)),
+			arg:  map[string]interface{}{"name": ""},
+			want: "name must be at least 1 character long",
+		},
+		{
+			name: "english plural",
+			ctx:  WithLocale(context.Background(), "en-GB"),
+			rule: name(PureString().LenGTE(3)),
+			arg:  map[string]interface{}{"name": "a"},
+			want: "name must be at least 3 characters long",
+		},
+		{
+			name: "german region falls back to the language",
+			ctx:  WithLocale(context.Background(), "de_AT"),
+			rule: name(PureString().LenGTE(3)),
+			arg:  map[string]interface{}{"name": "a"},
+			want: "name muss mindestens 3 Zeichen lang sein",
+		},
+		{
+			name: "japanese",
+			ctx:  WithLocale(context.Background(), "ja"),
+			rule: PureInt().GTE(5),
+			arg:  int64(1),
+			want: "valueは5以上である必要があります",
+		},
+		{
+			name: "polish few",
+			ctx:  WithTranslator(WithLocale(context.Background(), "pl"), bundle),
+			rule: name(PureString().LenGTE(3)),
+			arg:  map[string]interface{}{"name": "a"},
+			want: "name: 3 znaki",
+		},
+		{
+			name: "polish many",
+			ctx:  WithTranslator(WithLocale(context.Background(), "pl"), bundle),
+			rule: name(PureString().LenGTE(5)),
+			arg:  map[string]interface{}{"name": "a"},
+			want: "name: 5 znaków",
+		},
+		{
+			name: "message set by Msg",
+			ctx:  WithLocale(context.Background(), "de"),
+			rule: PureString().LenGTE(3).Msg("too short"),
+			arg:  "a",
+			want: "too short",
+		},
+		{
+			name: "without a locale",
+			ctx:  context.Background(),
+			rule: PureInt().GTE(5),
+			arg:  int64(1),
+			want: errIntGTE(5).Error(),
+		},
+		{
+			name: "locale without a catalog",
+			ctx:  WithLocale(context.Background(), "xx"),
+			rule: PureInt().GTE(5),
+			arg:  int64(1),
+			want: errIntGTE(5).Error(),
+		},
+		{
+			name: "password reasons",
+			ctx:  WithLocale(context.Background(), "en"),
+			rule: Password(PasswordPolicy{MinLength: 8, MinDigits: 1}),
+			arg:  "abc",
+			want: "password must have at least 8 characters; password must have at least 1 digit",
+		},
+	}
+
+	for _, test := range tests {
+		err := ApplyContext(test.ctx, test.rule, test.arg)
+		if err == nil || err.Error() != test.want {
+			t.Errorf("%s: ApplyContext() = %v, want %q", test.name, err, test.want)
+		}
+	}
+}
+
+func TestPluralRules(t *testing.T) {
+	tests := []struct {
+		language string
+		n        float64
+		want     string
+	}{
+		{"en", 1, "one"},
+		{"en", 0, "other"},
+		{"fr", 0, "one"},
+		{"fr", 2, "other"},
+		{"ja", 1, "other"},
+		{"ru", 21, "one"},
+		{"ru", 22, "few"},
+		{"ru", 11, "many"},
+		{"pl", 1, "one"},
+		{"pl", 24, "few"},
+		{"pl", 25, "many"},
+	}
+
+	for _, test := range tests {
+		if got := pluralRule(test.language)(test.n); got != test.want {
+			t.Errorf("pluralRule(%q)(%v) = %q, want %q", test.language, test.n, got, test.want)
+		}
+	}
+}
@@ -8,9 +8,13 @@ type IntRule struct {
 	// base : base is the name of the type from which the int value will be inferred.
 	base string
 	// whites : the list of whitelisted values for this rule.
-	whites []interface{}
+	whites whitelist
 	// checks : the list of checks to be performed as part of this rule.
 	checks []IntCheck
+	// null : how the rule treats null values.
+	null nullability
+	// safe : whether panics raised by the checks are recovered.
+	safe bool
 	// err : the error to be thrown if the rule fails.
 	err error
 }
@@ -21,7 +25,14 @@ type IntRule struct {
 // If the argument is one of the whitelisted values, no checks
 // will be performed upon it.
 func (i *IntRule) Allow(args ...interface{}) *IntRule {
-	i.whites = append(i.whites, args...)
+	i.whites.add(false, args...)
+	return i
+}
+
+// AllowLoose : Whitelists the provided values like Allow, but numbers match
+// numbers of any kind with the same value, e.g. int(5) matches int64(5) and float64(5).
+func (i *IntRule) AllowLoose(args ...interface{}) *IntRule {
+	i.whites.add(true, args...)
 	return i
 }
 
@@ -34,19 +45,70 @@ func (i *IntRule) AddCheck(check IntCheck) *IntRule {
 // WithError : Adds a custom error to the rule.
 // This custom error (if not nil) will be thrown on every check violation
 // instead of the original error.
+// It is returned as it is, without the code and the key path of the violation; MsgError keeps them.
 func (i *IntRule) WithError(err error) *IntRule {
 	i.err = err
 	return i
 }
 
+// Nullable : Makes the rule pass null values, i.e. nil or nil pointers.
+func (i *IntRule) Nullable() *IntRule {
+	i.null = nullAllowed
+	return i
+}
+
+// NotNull : Makes the rule fail null values, i.e. nil or nil pointers, with a dedicated error.
+func (i *IntRule) NotNull() *IntRule {
+	i.null = nullDenied
+	return i
+}
+
+// Recover : Makes the rule recover panics raised by its checks and nested rules,
+// and report them as a ValidationError with the CodeInternal code and the stack trace.
+func (i *IntRule) Recover() *IntRule {
+	i.safe = true
+	return i
+}
+
+// Msg : Replaces the message of the most recently added check with the template.
+// The template may refer to the params of the check, such as {min}, and to the key path as {field}.
+// Example: PureInt().GTE(18).Msg("{field} must be at least {min}")
+func (i *IntRule) Msg(template string) *IntRule {
+	if check := i.lastCheck(); check != nil {
+		i.checks[len(i.checks)-1] = func(arg int64) error { return withMessage(template, check(arg)) }
+	}
+	return i
+}
+
+// MsgError : Replaces the error of the most recently added check with the provided error.
+// The code, the params and the key path of the failure are kept.
+func (i *IntRule) MsgError(err error) *IntRule {
+	if check := i.lastCheck(); check != nil {
+		i.checks[len(i.checks)-1] = func(arg int64) error { return withError(err, check(arg)) }
+	}
+	return i
+}
+
 // Apply : Applies the rule on a given argument.
-func (i *IntRule) Apply(arg interface{}) error {
+// Pointers, driver.Valuer implementations and types with a registered Converter
+// are unwrapped before validation.
+func (i *IntRule) Apply(arg interface{}) (err error) {
+	if i.safe {
+		defer recoverPanic(&err)
+	}
+	arg, isNull := normalize(arg, i.base)
 	if i.isWhitelisted(arg) {
 		return nil
 	}
+	if isNull && i.null == nullAllowed {
+		return nil
+	}
+	if isNull && i.null == nullDenied {
+		return orErr(i.err, coded(CodeNotNull, nil, errNotNull()))
+	}
 	intVal, err := toInt64(arg, i.base)
 	if err != nil {
-		return orErr(i.err, errInt64(i.base))
+		return orErr(i.err, coded(CodeType, Params{"type": i.base}, errInt64(i.base)))
 	}
 
 	if err := i.performChecks(intVal); err != nil {
@@ -78,13 +140,21 @@ func PureInt() *IntRule {
 
 // IntRule PRIVATE METHODS ##########################################
 
-func (i *IntRule) isWhitelisted(value interface{}) bool {
-	for _, white := range i.whites {
-		if white == value {
-			return true
-		}
+// addCheck : adds a check whose errors are reported as a ValidationError with the code and the params.
+func (i *IntRule) addCheck(code string, params Params, check IntCheck) *IntRule {
+	return i.AddCheck(func(arg int64) error { return coded(code, params, check(arg)) })
+}
+
+// lastCheck : returns the most recently added check, or nil if there is none.
+func (i *IntRule) lastCheck() IntCheck {
+	if len(i.checks) == 0 {
+		return nil
 	}
-	return false
+	return i.checks[len(i.checks)-1]
+}
+
+func (i *IntRule) isWhitelisted(value interface{}) bool {
+	return i.whites.contains(value)
 }
 
 func (i *IntRule) performChecks(arg int64) error {
@@ -103,7 +173,7 @@ func (i *IntRule) performChecks(arg int64) error {
 
 // GTE : Adds a '>=' check to the rule.
 func (i *IntRule) GTE(value int64) *IntRule {
-	i.AddCheck(func(arg int64) error {
+	i.addCheck(CodeGTE, Params{"min": value}, func(arg int64) error {
 		if arg < value {
 			return errIntGTE(value)
 		}
@@ -114,7 +184,7 @@ func (i *IntRule) GTE(value int64) *IntRule {
 
 // LTE : Adds a '<=' check to the rule.
 func (i *IntRule) LTE(value int64) *IntRule {
-	i.AddCheck(func(arg int64) error {
+	i.addCheck(CodeLTE, Params{"max": value}, func(arg int64) error {
 		if arg > value {
 			return errIntLTE(value)
 		}
@@ -125,7 +195,7 @@ func (i *IntRule) LTE(value int64) *IntRule {
 
 // GT : Adds a '>' check to the rule.
 func (i *IntRule) GT(value int64) *IntRule {
-	i.AddCheck(func(arg int64) error {
+	i.addCheck(CodeGT, Params{"min": value}, func(arg int64) error {
 		if arg <= value {
 			return errIntGT(value)
 		}
@@ -136,7 +206,7 @@ func (i *IntRule) GT(value int64) *IntRule {
 
 // LT : Adds a '<' check to the rule.
 func (i *IntRule) LT(value int64) *IntRule {
-	i.AddCheck(func(arg int64) error {
+	i.addCheck(CodeLT, Params{"max": value}, func(arg int64) error {
 		if arg >= value {
 			return errIntLT(value)
 		}
@@ -147,7 +217,7 @@ func (i *IntRule) LT(value int64) *IntRule {
 
 // Except : Invalidates if arg == provided value
 func (i *IntRule) Except(value int64) *IntRule {
-	i.AddCheck(func(arg int64) error {
+	i.addCheck(CodeExcept, Params{"value": value}, func(arg int64) error {
 		if arg == value {
 			return errIntExcept(value)
 		}
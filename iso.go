@@ -0,0 +1,236 @@
+package valkyrie
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	// The embedded copy of the IANA time zone database keeps TimeZone independent of the system files.
+	_ "time/tzdata"
+)
+
+// CountryCodeFormat : Represents the ISO 3166-1 code format accepted by a CountryCode check.
+type CountryCodeFormat int
+
+const (
+	// CountryAlpha2 : The two letter code. Example: "DE"
+	CountryAlpha2 CountryCodeFormat = iota
+	// CountryAlpha3 : The three letter code. Example: "DEU"
+	CountryAlpha3
+	// CountryNumeric : The three digit code. Example: "276"
+	CountryNumeric
+)
+
+// countryLookup : the sets of country codes per format, built from the countryCodes table.
+var countryLookup = func() map[CountryCodeFormat]map[string]struct{} {
+	lookup := map[CountryCodeFormat]map[string]struct{}{
+		CountryAlpha2:  make(map[string]struct{}, len(countryCodes)),
+		CountryAlpha3:  make(map[string]struct{}, len(countryCodes)),
+		CountryNumeric: make(map[string]struct{}, len(countryCodes)),
+	}
+	for _, code := range countryCodes {
+		lookup[CountryAlpha2][code.alpha2] = struct{}{}
+		lookup[CountryAlpha3][code.alpha3] = struct{}{}
+		lookup[CountryNumeric][code.numeric] = struct{}{}
+	}
+	return lookup
+}()
+
+// currencyLookup : the set of currency codes, built from the currencyCodes table.
+var currencyLookup = func() map[string]struct{} {
+	lookup := make(map[string]struct{}, len(currencyCodes))
+	for _, code := range currencyCodes {
+		lookup[code] = struct{}{}
+	}
+	return lookup
+}()
+
+// grandfatheredTags : the irregular and regular grandfathered tags of RFC 5646, in lowercase.
+var grandfatheredTags = map[string]struct{}{
+	"en-gb-oed": {}, "i-ami": {}, "i-bnn": {}, "i-default": {}, "i-enochian": {}, "i-hak": {},
+	"i-klingon": {}, "i-lux": {}, "i-mingo": {}, "i-navajo": {}, "i-pwn": {}, "i-tao": {}, "i-tay": {},
+	"i-tsu": {}, "sgn-be-fr": {}, "sgn-be-nl": {}, "sgn-ch-de": {}, "art-lojban": {}, "cel-gaulish": {},
+	"no-bok": {}, "no-nyn": {}, "zh-guoyu": {}, "zh-hakka": {}, "zh-min": {}, "zh-min-nan": {}, "zh-xiang": {},
+}
+
+// timeZoneCache : the time zone names already known to be valid.
+var timeZoneCache sync.Map
+
+// isCountryCode : checks if the string is an assigned ISO 3166-1 code of the given format.
+func isCountryCode(str string, format CountryCodeFormat) bool {
+	_, exists := countryLookup[format][str]
+	return exists
+}
+
+// isCurrencyCode : checks if the string is an active ISO 4217 code.
+func isCurrencyCode(str string) bool {
+	_, exists := currencyLookup[str]
+	return exists
+}
+
+// isLanguageTag : checks if the string is a well-formed BCP 47 language tag (RFC 5646, section 2.1)
+// without duplicate variants or extension singletons. Subtags are not checked against the registry.
+func isLanguageTag(str string) bool {
+	lower := strings.ToLower(str)
+	if _, exists := grandfatheredTags[lower]; exists {
+		return true
+	}
+	subtags := strings.Split(lower, "-")
+	for _, subtag := range subtags {
+		if len(subtag) == 0 || len(subtag) > 8 || !isAlphanumeric(subtag) {
+			return false
+		}
+	}
+	if subtags[0] == "x" {
+		return isPrivateUse(subtags)
+	}
+
+	// language, with up to three extended language subtags after a 2-3 letter language.
+	i := 0
+	if !isAlpha(subtags[i]) || len(subtags[i]) == 1 {
+		return false
+	}
+	if len(subtags[i]) <= 3 {
+		for j := 0; j < 3 && i+1 < len(subtags) && len(subtags[i+1]) == 3 && isAlpha(subtags[i+1]); j++ {
+			i++
+		}
+	}
+	i++
+	// script
+	if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+		i++
+	}
+	// region
+	if i < len(subtags) && ((len(subtags[i]) == 2 && isAlpha(subtags[i])) ||
+		(len(subtags[i]) == 3 && isNumeric(subtags[i]))) {
+		i++
+	}
+	// variants
+	variants := map[string]struct{}{}
+	for ; i < len(subtags) && isVariant(subtags[i]); i++ {
+		if _, exists := variants[subtags[i]]; exists {
+			return false
+		}
+		variants[subtags[i]] = struct{}{}
+	}
+	// extensions
+	singletons := map[string]struct{}{}
+	for i < len(subtags) && len(subtags[i]) == 1 && subtags[i] != "x" {
+		if _, exists := singletons[subtags[i]]; exists {
+			return false
+		}
+		singletons[subtags[i]] = struct{}{}
+		i++
+		start := i
+		for i < len(subtags) && len(subtags[i]) >= 2 {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+	// private use
+	if i < len(subtags) {
+		return isPrivateUse(subtags[i:])
+	}
+	return true
+}
+
+// isPrivateUse : checks the "x-" private use section of a language tag.
+func isPrivateUse(subtags []string) bool {
+	return len(subtags) >= 2 && subtags[0] == "x"
+}
+
+// isVariant : checks if the subtag is a 5-8 character variant or a 4 character one starting with a digit.
+func isVariant(subtag string) bool {
+	return len(subtag) >= 5 || (len(subtag) == 4 && isDigit(subtag[0]))
+}
+
+// isTimeZone : checks if the string is an IANA time zone name known to the embedded database.
+func isTimeZone(str string) bool {
+	if str == "" || str == "Local" {
+		return false
+	}
+	if _, exists := timeZoneCache.Load(str); exists {
+		return true
+	}
+	if _, err := time.LoadLocation(str); err != nil {
+		return false
+	}
+	timeZoneCache.Store(str, struct{}{})
+	return true
+}
+
+func isAlpha(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if c := str[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumeric(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if !isDigit(str[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if !isDigit(str[i]) && !isAlpha(str[i:i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// CountryCode : Adds an ISO 3166-1 country code check on the string.
+// Alpha codes must be uppercase.
+func (s *StringRule) CountryCode(format CountryCodeFormat) *StringRule {
+	s.addCheck(CodeCountryCode, nil, func(arg string) error {
+		if !isCountryCode(arg, format) {
+			return errStringCountry()
+		}
+		return nil
+	})
+	return s
+}
+
+// CurrencyCode : Adds an ISO 4217 currency code check on the string. The code must be uppercase.
+func (s *StringRule) CurrencyCode() *StringRule {
+	s.addCheck(CodeCurrencyCode, nil, func(arg string) error {
+		if !isCurrencyCode(arg) {
+			return errStringCurrency()
+		}
+		return nil
+	})
+	return s
+}
+
+// LanguageTag : Adds a check that the string is a well-formed BCP 47 language tag.
+// Example: "en", "zh-Hant-TW", "sr-Latn-RS", "de-CH-1996"
+func (s *StringRule) LanguageTag() *StringRule {
+	s.addCheck(CodeLanguageTag, nil, func(arg string) error {
+		if !isLanguageTag(arg) {
+			return errStringLanguage()
+		}
+		return nil
+	})
+	return s
+}
+
+// TimeZone : Adds a check that the string is an IANA time zone name, such as "Europe/Berlin" or "UTC".
+// The time zone database is embedded in the package, so no system files are needed.
+func (s *StringRule) TimeZone() *StringRule {
+	s.addCheck(CodeTimeZone, nil, func(arg string) error {
+		if !isTimeZone(arg) {
+			return errStringTimeZone()
+		}
+		return nil
+	})
+	return s
+}
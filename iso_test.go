@@ -0,0 +1,77 @@
+package valkyrie
+
+import "testing"
+
+func TestISOCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		rule *StringRule
+		arg  string
+		want bool
+	}{
+		{"alpha2", PureString().CountryCode(CountryAlpha2), "DE", true},
+		{"alpha2 lower case", PureString().CountryCode(CountryAlpha2), "de", false},
+		{"alpha2 unassigned", PureString().CountryCode(CountryAlpha2), "XX", false},
+		{"alpha3", PureString().CountryCode(CountryAlpha3), "DEU", true},
+		{"alpha3 given alpha2", PureString().CountryCode(CountryAlpha3), "DE", false},
+		{"numeric", PureString().CountryCode(CountryNumeric), "276", true},
+		{"numeric unassigned", PureString().CountryCode(CountryNumeric), "999", false},
+
+		{"currency", PureString().CurrencyCode(), "EUR", true},
+		{"currency lower case", PureString().CurrencyCode(), "eur", false},
+		{"currency unknown", PureString().CurrencyCode(), "ABC", false},
+
+		{"time zone", PureString().TimeZone(), "Europe/Berlin", true},
+		{"time zone utc", PureString().TimeZone(), "UTC", true},
+		{"time zone nested", PureString().TimeZone(), "America/Argentina/Buenos_Aires", true},
+		{"time zone local", PureString().TimeZone(), "Local", false},
+		{"time zone empty", PureString().TimeZone(), "", false},
+		{"time zone unknown", PureString().TimeZone(), "Mars/Olympus_Mons", false},
+	}
+
+	for _, test := range tests {
+		if got := test.rule.Apply(test.arg) == nil; got != test.want {
+			t.Errorf("%s: Apply(%q) passed = %v, want %v", test.name, test.arg, got, test.want)
+		}
+	}
+}
+
+func TestLanguageTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{"en", true},
+		{"EN-us", true},
+		{"zh-Hant-TW", true},
+		{"sr-Latn-RS", true},
+		{"de-CH-1996", true},
+		{"zh-yue-HK", true},
+		{"es-419", true},
+		{"sl-rozaj-biske", true},
+		{"en-a-bbb-b-ccc", true},
+		{"en-US-x-twain", true},
+		{"x-whatever", true},
+		{"i-klingon", true},
+		{"en-GB-oed", true},
+
+		{"", false},
+		{"e", false},
+		{"en-", false},
+		{"en--US", false},
+		{"1996", false},
+		{"abcdefghi", false},
+		{"en_US", false},
+		{"de-1996-1996", false},
+		{"en-a-bbb-a-ccc", false},
+		{"en-a", false},
+		{"en-x", false},
+		{"x", false},
+	}
+
+	for _, test := range tests {
+		if got := PureString().LanguageTag().Apply(test.tag) == nil; got != test.want {
+			t.Errorf("LanguageTag().Apply(%q) passed = %v, want %v", test.tag, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package valkyrie
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	// The hash implementations used by the supported algorithms.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// JWTOptions : Represents the validations performed by a JWT check in addition to the structure.
+type JWTOptions struct {
+	// Header : the rule for the decoded header, a map[string]interface{}. Optional.
+	Header Rule
+	// Claims : the rule for the decoded payload, a map[string]interface{}. Optional.
+	// Example: PureMap().Key("exp", true, PureFloat()).Key("aud", true, PureString().Allow("api").Blind())
+	Claims Rule
+	// Now : the clock used for the "exp", "nbf" and "iat" claims. Defaults to time.Now.
+	Now func() time.Time
+	// Leeway : the allowed clock skew for the "exp", "nbf" and "iat" claims.
+	Leeway time.Duration
+	// Keys : the keys to verify the signature with, which are []byte for HS256/384/512,
+	// *rsa.PublicKey for RS256/384/512 and PS256/384/512, and *ecdsa.PublicKey for ES256/384/512.
+	// The signature must be valid for at least one of them. If empty, the signature is not verified.
+	Keys []interface{}
+	// Algorithms : the accepted "alg" header values. If empty, every supported algorithm is accepted.
+	Algorithms []string
+}
+
+// jwtAlgorithm : the hash and the key type of a JWS algorithm (RFC 7518).
+type jwtAlgorithm struct {
+	hash crypto.Hash
+	// family : "HS", "RS", "PS" or "ES".
+	family string
+}
+
+// jwtAlgorithms : the supported JWS algorithms.
+var jwtAlgorithms = map[string]jwtAlgorithm{
+	"HS256": {crypto.SHA256, "HS"}, "HS384": {crypto.SHA384, "HS"}, "HS512": {crypto.SHA512, "HS"},
+	"RS256": {crypto.SHA256, "RS"}, "RS384": {crypto.SHA384, "RS"}, "RS512": {crypto.SHA512, "RS"},
+	"PS256": {crypto.SHA256, "PS"}, "PS384": {crypto.SHA384, "PS"}, "PS512": {crypto.SHA512, "PS"},
+	"ES256": {crypto.SHA256, "ES"}, "ES384": {crypto.SHA384, "ES"}, "ES512": {crypto.SHA512, "ES"},
+}
+
+// jwtCurveBits : the curve size of each ECDSA algorithm.
+var jwtCurveBits = map[string]int{"ES256": 256, "ES384": 384, "ES512": 521}
+
+// maxJWTSeconds : the largest NumericDate accepted, beyond which a float64 no longer holds every integer.
+const maxJWTSeconds = 1 << 53
+
+// validateJWT : decodes the compact serialization of a JWT and validates it as per the options.
+func validateJWT(token string, options JWTOptions) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errStringJWT("expected 3 dot separated parts")
+	}
+	header, err := decodeJWTPart(parts[0])
+	if err != nil {
+		return errStringJWT("malformed header")
+	}
+	claims, err := decodeJWTPart(parts[1])
+	if err != nil {
+		return errStringJWT("malformed payload")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errStringJWT("malformed signature")
+	}
+	alg, ok := header["alg"].(string)
+	if !ok {
+		return errStringJWT("missing alg header")
+	}
+
+	if len(options.Keys) > 0 || len(options.Algorithms) > 0 {
+		if !isJWTAlgorithmAllowed(alg, options.Algorithms) {
+			return errStringJWT("algorithm " + alg + " is not accepted")
+		}
+	}
+	if len(options.Keys) > 0 && !verifyJWTSignature(alg, parts[0]+"."+parts[1], signature, options.Keys) {
+		return errStringJWT("invalid signature")
+	}
+	if err := validateJWTTimes(claims, options); err != nil {
+		return err
+	}
+
+	if options.Header != nil {
+		if err := options.Header.Apply(header); err != nil {
+			return err
+		}
+	}
+	if options.Claims != nil {
+		if err := options.Claims.Apply(claims); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeJWTPart : decodes a base64url encoded JSON object.
+func decodeJWTPart(part string) (map[string]interface{}, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(decoded, &obj); err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, errEmpty
+	}
+	return obj, nil
+}
+
+// isJWTAlgorithmAllowed : checks that the algorithm is supported and accepted. "none" is never accepted.
+func isJWTAlgorithmAllowed(alg string, algorithms []string) bool {
+	if _, supported := jwtAlgorithms[alg]; !supported {
+		return false
+	}
+	if len(algorithms) == 0 {
+		return true
+	}
+	for _, allowed := range algorithms {
+		if allowed == alg {
+			return true
+		}
+	}
+	return false
+}
+
+// validateJWTTimes : checks the registered time claims, which are optional but must be numbers if present.
+func validateJWTTimes(claims map[string]interface{}, options JWTOptions) error {
+	now := time.Now
+	if options.Now != nil {
+		now = options.Now
+	}
+	current := now()
+
+	for _, name := range []string{"exp", "nbf", "iat"} {
+		value, exists := claims[name]
+		if !exists {
+			continue
+		}
+		seconds, ok := value.(float64)
+		if !ok {
+			return errStringJWT(name + " claim is not a number")
+		}
+		instant, ok := jwtNumericDate(seconds)
+		if !ok {
+			return errStringJWT(name + " claim is out of range")
+		}
+		switch {
+		case name == "exp" && !current.Before(instant.Add(options.Leeway)):
+			return errStringJWT("token is expired")
+		case name == "nbf" && current.Before(instant.Add(-options.Leeway)):
+			return errStringJWT("token is not valid yet")
+		case name == "iat" && current.Add(options.Leeway).Before(instant):
+			return errStringJWT("token is issued in the future")
+		}
+	}
+	return nil
+}
+
+// jwtNumericDate : converts a NumericDate, i.e. seconds since the epoch with an optional fraction, into a time.
+// It reports false for NaN, infinities and values beyond the integers exactly representable by a float64.
+func jwtNumericDate(seconds float64) (time.Time, bool) {
+	if math.IsNaN(seconds) || seconds > maxJWTSeconds || seconds < -maxJWTSeconds {
+		return time.Time{}, false
+	}
+	whole, fraction := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(fraction*float64(time.Second))), true
+}
+
+// verifyJWTSignature : verifies the signature of the signing input with any of the keys usable by the algorithm.
+func verifyJWTSignature(alg string, signingInput string, signature []byte, keys []interface{}) bool {
+	algorithm := jwtAlgorithms[alg]
+	hasher := algorithm.hash.New()
+	hasher.Write([]byte(signingInput))
+	digest := hasher.Sum(nil)
+
+	for _, key := range keys {
+		switch key := key.(type) {
+		case []byte:
+			if algorithm.family != "HS" {
+				continue
+			}
+			mac := hmac.New(algorithm.hash.New, key)
+			mac.Write([]byte(signingInput))
+			if hmac.Equal(mac.Sum(nil), signature) {
+				return true
+			}
+		case *rsa.PublicKey:
+			switch algorithm.family {
+			case "RS":
+				if rsa.VerifyPKCS1v15(key, algorithm.hash, digest, signature) == nil {
+					return true
+				}
+			case "PS":
+				options := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}
+				if rsa.VerifyPSS(key, algorithm.hash, digest, signature, options) == nil {
+					return true
+				}
+			}
+		case *ecdsa.PublicKey:
+			if algorithm.family != "ES" || key.Curve.Params().BitSize != jwtCurveBits[alg] {
+				continue
+			}
+			// The signature is the concatenation of the fixed size R and S values.
+			size := (jwtCurveBits[alg] + 7) / 8
+			if len(signature) != 2*size {
+				continue
+			}
+			r := new(big.Int).SetBytes(signature[:size])
+			s := new(big.Int).SetBytes(signature[size:])
+			if ecdsa.Verify(key, digest, r, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// JWT : Adds a JSON Web Token check on the string.
+// The token must be in the compact serialization with a JSON object header carrying "alg" and a JSON
+// object payload. The "exp", "nbf" and "iat" claims, when present, are checked against the clock.
+// The signature is verified only if keys are provided, in which case "alg": "none" is rejected.
+// Example:
+//
+//	PureString().JWT(JWTOptions{
+//		Claims: PureMap().Key("sub", true, PureString()),
+//		Keys:   []interface{}{secret},
+//		Leeway: time.Minute,
+//	})
+func (s *StringRule) JWT(options JWTOptions) *StringRule {
+	s.addCheck(CodeJWT, nil, func(arg string) error {
+		return validateJWT(arg, options)
+	})
+	return s
+}
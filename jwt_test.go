@@ -0,0 +1,147 @@
+package valkyrie
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestJWT(t *testing.T) {
+	secret := []byte("secret")
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	now := func() time.Time { return time.Unix(1700000000, 0) }
+
+	tests := []struct {
+		name    string
+		token   string
+		options JWTOptions
+		want    bool
+	}{
+		{
+			name:    "hs256",
+			token:   signHS256(`{"alg":"HS256"}`, `{"sub":"1","exp":1700000060}`, secret),
+			options: JWTOptions{Keys: []interface{}{secret}, Now: now},
+			want:    true,
+		},
+		{
+			name:    "hs256 wrong key",
+			token:   signHS256(`{"alg":"HS256"}`, `{"sub":"1"}`, secret),
+			options: JWTOptions{Keys: []interface{}{[]byte("other")}},
+		},
+		{
+			name:    "hs256 key of another family",
+			token:   signHS256(`{"alg":"HS256"}`, `{"sub":"1"}`, secret),
+			options: JWTOptions{Keys: []interface{}{&ecKey.PublicKey}},
+		},
+		{
+			name:    "algorithm not accepted",
+			token:   signHS256(`{"alg":"HS256"}`, `{"sub":"1"}`, secret),
+			options: JWTOptions{Keys: []interface{}{secret}, Algorithms: []string{"ES256"}},
+		},
+		{
+			name:    "es256",
+			token:   signES256(t, `{"alg":"ES256"}`, `{"sub":"1"}`, ecKey),
+			options: JWTOptions{Keys: []interface{}{secret, &ecKey.PublicKey}},
+			want:    true,
+		},
+		{
+			name:    "none without keys",
+			token:   jwtPart(`{"alg":"none"}`) + "." + jwtPart(`{"sub":"1"}`) + ".",
+			options: JWTOptions{},
+			want:    true,
+		},
+		{
+			name:    "none with keys",
+			token:   jwtPart(`{"alg":"none"}`) + "." + jwtPart(`{"sub":"1"}`) + ".",
+			options: JWTOptions{Keys: []interface{}{secret}},
+		},
+		{
+			name:    "expired",
+			token:   signHS256(`{"alg":"HS256"}`, `{"exp":1699999990}`, secret),
+			options: JWTOptions{Now: now},
+		},
+		{
+			name:    "expired within the leeway",
+			token:   signHS256(`{"alg":"HS256"}`, `{"exp":1699999990}`, secret),
+			options: JWTOptions{Now: now, Leeway: time.Minute},
+			want:    true,
+		},
+		{
+			name:    "not valid yet",
+			token:   signHS256(`{"alg":"HS256"}`, `{"nbf":1700000100}`, secret),
+			options: JWTOptions{Now: now},
+		},
+		{
+			name:    "issued in the future",
+			token:   signHS256(`{"alg":"HS256"}`, `{"iat":1700000100.5}`, secret),
+			options: JWTOptions{Now: now},
+		},
+		{
+			name:    "exp out of range",
+			token:   signHS256(`{"alg":"HS256"}`, `{"exp":1e300}`, secret),
+			options: JWTOptions{Now: now},
+		},
+		{
+			name:    "exp not a number",
+			token:   signHS256(`{"alg":"HS256"}`, `{"exp":"tomorrow"}`, secret),
+			options: JWTOptions{Now: now},
+		},
+		{
+			name:    "claims rule",
+			token:   signHS256(`{"alg":"HS256"}`, `{"aud":"web"}`, secret),
+			options: JWTOptions{Claims: PureMap().Key("aud", true, PureString().Allow("api").Blind())},
+		},
+		{
+			name:    "header rule",
+			token:   signHS256(`{"alg":"HS256","typ":"JWT"}`, `{}`, secret),
+			options: JWTOptions{Header: PureMap().Key("typ", true, PureString().Allow("JWT").Blind())},
+			want:    true,
+		},
+		{name: "two parts", token: jwtPart(`{"alg":"HS256"}`) + "." + jwtPart(`{}`)},
+		{name: "payload not an object", token: jwtPart(`{"alg":"HS256"}`) + "." + jwtPart(`[1]`) + "."},
+		{name: "payload null", token: jwtPart(`{"alg":"HS256"}`) + "." + jwtPart(`null`) + "."},
+		{name: "missing alg", token: jwtPart(`{"typ":"JWT"}`) + "." + jwtPart(`{}`) + "."},
+		{name: "padded signature", token: jwtPart(`{"alg":"HS256"}`) + "." + jwtPart(`{}`) + ".AA=="},
+	}
+
+	for _, test := range tests {
+		if got := PureString().JWT(test.options).Apply(test.token) == nil; got != test.want {
+			t.Errorf("%s: JWT().Apply(%q) passed = %v, want %v", test.name, test.token, got, test.want)
+		}
+	}
+}
+
+// jwtPart : encodes a part of a JWT.
+func jwtPart(str string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(str))
+}
+
+// signHS256 : builds a JWT signed with HMAC SHA-256.
+func signHS256(header, claims string, secret []byte) string {
+	input := jwtPart(header) + "." + jwtPart(claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// signES256 : builds a JWT signed with ECDSA P-256 SHA-256.
+func signES256(t *testing.T, header, claims string, key *ecdsa.PrivateKey) string {
+	input := jwtPart(header) + "." + jwtPart(claims)
+	digest := sha256.Sum256([]byte(input))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
@@ -1,16 +1,52 @@
 package valkyrie
 
+import "fmt"
+
 // MapCheck : Represents a function that performs a validation check on a map[string]interface{}.
 type MapCheck func(map[string]interface{}) error
 
 // MapRule : Rule interface implementation for a map[string]interface{}.
 type MapRule struct {
+	whites whitelist
 	checks []MapCheck
+	null   nullability
+	safe   bool
+	all    bool
+	keys   []string
 	err    error
 }
 
+// KeyOption : Represents an option that changes how MapRule.Key treats a key present with a null value.
+// By default, the null value is validated by the key's rule like any other value.
+type KeyOption int
+
+const (
+	// NullAsMissing : A key present with a null value is treated as if it were missing.
+	// So {"x": null} fails a required key and passes an optional one, the same way as {}.
+	NullAsMissing KeyOption = iota + 1
+	// NullAllowed : A key present with a null value passes without applying the key's rule.
+	NullAllowed
+	// NullDenied : A key present with a null value fails, even if the key's rule is Nullable.
+	NullDenied
+)
+
 // MapRule PRIMARY PUBLIC METHODS ###################################
 
+// Allow : Whitelists the provided values for a rule.
+// If the argument is one of the whitelisted values, no checks
+// will be performed upon it. Maps are compared by deep equality.
+func (m *MapRule) Allow(args ...interface{}) *MapRule {
+	m.whites.add(false, args...)
+	return m
+}
+
+// AllowLoose : Whitelists the provided values like Allow, but numbers match
+// numbers of any kind with the same value, e.g. int(5) matches int64(5) and float64(5).
+func (m *MapRule) AllowLoose(args ...interface{}) *MapRule {
+	m.whites.add(true, args...)
+	return m
+}
+
 // AddCheck : Adds a custom check function to the rule.
 func (m *MapRule) AddCheck(check MapCheck) *MapRule {
 	m.checks = append(m.checks, check)
@@ -20,16 +56,78 @@ func (m *MapRule) AddCheck(check MapCheck) *MapRule {
 // WithError : Adds a custom error to the rule.
 // This custom error (if not nil) will be thrown on every check violation
 // instead of the original error.
+// It is returned as it is, without the code and the key path of the violation; MsgError keeps them.
 func (m *MapRule) WithError(err error) *MapRule {
 	m.err = err
 	return m
 }
 
+// Nullable : Makes the rule pass null values, i.e. nil or nil pointers.
+func (m *MapRule) Nullable() *MapRule {
+	m.null = nullAllowed
+	return m
+}
+
+// NotNull : Makes the rule fail null values, i.e. nil or nil pointers, with a dedicated error.
+func (m *MapRule) NotNull() *MapRule {
+	m.null = nullDenied
+	return m
+}
+
+// Recover : Makes the rule recover panics raised by its checks and nested rules,
+// and report them as a ValidationError with the CodeInternal code and the stack trace.
+// The key paths are reported down to the deepest MapRule that also recovers panics.
+func (m *MapRule) Recover() *MapRule {
+	m.safe = true
+	return m
+}
+
+// AllErrors : Makes the rule perform all its checks, instead of stopping at the first failure,
+// and report the failures together as a MultiError. A single failure is reported as is.
+func (m *MapRule) AllErrors() *MapRule {
+	m.all = true
+	return m
+}
+
+// Msg : Replaces the message of the most recently added check with the template.
+// The template may refer to the params of the check, such as {min}, and to the key path as {field}.
+// Example: PureMap().Key("name", true, PureString()).Msg("{field} is invalid")
+func (m *MapRule) Msg(template string) *MapRule {
+	if check := m.lastCheck(); check != nil {
+		m.checks[len(m.checks)-1] = func(arg map[string]interface{}) error { return withMessage(template, check(arg)) }
+	}
+	return m
+}
+
+// MsgError : Replaces the error of the most recently added check with the provided error.
+// The code, the params and the key path of the failure are kept.
+func (m *MapRule) MsgError(err error) *MapRule {
+	if check := m.lastCheck(); check != nil {
+		m.checks[len(m.checks)-1] = func(arg map[string]interface{}) error { return withError(err, check(arg)) }
+	}
+	return m
+}
+
 // Apply : Applies the rule on a given argument.
-func (m *MapRule) Apply(arg interface{}) error {
+// Pointers, driver.Valuer implementations and types with a registered Converter
+// are unwrapped before validation.
+func (m *MapRule) Apply(arg interface{}) (err error) {
+	if m.safe {
+		defer recoverPanic(&err)
+	}
+	arg, isNull := normalize(arg, "")
+	if m.isWhitelisted(arg) {
+		return nil
+	}
+	if isNull && m.null == nullAllowed {
+		return nil
+	}
+	if isNull && m.null == nullDenied {
+		return orErr(m.err, coded(CodeNotNull, nil, errNotNull()))
+	}
 	mapVal, ok := arg.(map[string]interface{})
 	if !ok {
-		return orErr(m.err, errMap())
+		return orErr(m.err, coded(CodeType, Params{"type": "map"}, errMap()))
 	}
 
 	if err := m.performChecks(mapVal); err != nil {
@@ -38,6 +136,14 @@ func (m *MapRule) Apply(arg interface{}) error {
 	return nil
 }
 
+// ApplyAll : Applies the rule like Apply, but performs all its checks and reports the failures together
+// like AllErrors, without changing the rule. So a rule shared with other callers keeps its behaviour.
+func (m *MapRule) ApplyAll(arg interface{}) error {
+	copied := *m
+	copied.all = true
+	return copied.Apply(arg)
+}
+
 // MapRule CONSTRUCTORS #############################################
 
 // PureMap : Creates an MapRule which expects the arg to be a map[string]interface{}.
@@ -47,31 +153,116 @@ func PureMap() *MapRule {
 
 // MapRule PRIVATE METHODS ##########################################
 
+// lastCheck : returns the most recently added check, or nil if there is none.
+func (m *MapRule) lastCheck() MapCheck {
+	if len(m.checks) == 0 {
+		return nil
+	}
+	return m.checks[len(m.checks)-1]
+}
+
+func (m *MapRule) isWhitelisted(value interface{}) bool {
+	return m.whites.contains(value)
+}
+
 func (m *MapRule) performChecks(arg map[string]interface{}) error {
+	var errs MultiError
 	for _, check := range m.checks {
 		if check == nil {
 			continue
 		}
 		if err := check(arg); err != nil {
-			return err
+			if !m.all {
+				return err
+			}
+			if multi, ok := err.(MultiError); ok {
+				errs = append(errs, multi...)
+				continue
+			}
+			errs = append(errs, err)
 		}
 	}
-	return nil
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return errs
 }
 
-// MapRule UTILITY PUBLIC METHODS  ##################################
+func (m *MapRule) keyCheck(keyName string, required bool, ruleFunc func(map[string]interface{}) Rule,
+	options []KeyOption) *MapRule {
+	var option KeyOption
+	if len(options) > 0 {
+		option = options[len(options)-1]
+	}
+
+	rule := m
+	if !containsString(m.keys, keyName) {
+		m.keys = append(m.keys, keyName)
+	}
+	m.AddCheck(func(m map[string]interface{}) (err error) {
+		defer func() { err = withKeyPath(keyName, err) }()
+		if rule.safe {
+			defer recoverPanic(&err)
+		}
 
-// Key : Adds a check to a specific key in the map.
-func (m *MapRule) Key(keyName string, required bool, rule Rule) *MapRule {
-	m.AddCheck(func(m map[string]interface{}) error {
 		value, exists := m[keyName]
+		if exists && option != 0 {
+			if _, isNull := normalize(value, ""); isNull {
+				switch option {
+				case NullAsMissing:
+					exists = false
+				case NullAllowed:
+					return nil
+				case NullDenied:
+					return coded(CodeNotNull, Params{"key": keyName}, errMapKeyNull(keyName))
+				}
+			}
+		}
 		if !exists && required {
-			return errMapKeyMissing(keyName)
+			return coded(CodeRequired, Params{"key": keyName}, errMapKeyMissing(keyName))
 		}
 		if !exists {
 			return nil
 		}
-		return rule.Apply(value)
+		keyRule := ruleFunc(m)
+		if isNilRule(keyRule) {
+			return &ValidationError{Code: CodeInternal, Err: errInternal(errMapKeyRuleNil(keyName))}
+		}
+		return keyRule.Apply(value)
 	})
 	return m
 }
+
+// MapRule UTILITY PUBLIC METHODS  ##################################
+
+// Key : Adds a check to a specific key in the map.
+// The options change how a key present with a null value is treated. If several are given, the last one wins.
+// It panics if the rule is nil.
+func (m *MapRule) Key(keyName string, required bool, rule Rule, options ...KeyOption) *MapRule {
+	if isNilRule(rule) {
+		panic(fmt.Sprintf("valkyrie: nil rule for key '%s'", keyName))
+	}
+	return m.keyCheck(keyName, required, func(map[string]interface{}) Rule { return rule }, options)
+}
+
+// KeyFunc : Adds a check to a specific key in the map, validated by the rule returned by ruleFunc.
+// The rule is built from the whole map on every application, which allows the validation of
+// a key to depend upon its sibling keys. It panics if ruleFunc is nil.
+// If ruleFunc returns a nil rule, the key fails with CodeInternal.
+func (m *MapRule) KeyFunc(keyName string, required bool, ruleFunc func(m map[string]interface{}) Rule,
+	options ...KeyOption) *MapRule {
+	if ruleFunc == nil {
+		panic(fmt.Sprintf("valkyrie: nil rule func for key '%s'", keyName))
+	}
+	return m.keyCheck(keyName, required, ruleFunc, options)
+}
+
+// Keys : Returns the names of the keys checked by Key and KeyFunc, in the order they were first added.
+func (m *MapRule) Keys() []string {
+	keys := make([]string, len(m.keys))
+	copy(keys, m.keys)
+	return keys
+}
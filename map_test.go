@@ -0,0 +1,84 @@
+package valkyrie
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestKeyFunc(t *testing.T) {
+	rule := PureMap().KeyFunc("end", true, func(m map[string]interface{}) Rule {
+		start, ok := m["start"].(int64)
+		if !ok {
+			return nil
+		}
+		return PureInt().GTE(start)
+	})
+
+	tests := []struct {
+		name string
+		arg  map[string]interface{}
+		// code : the code of the failure, or "" if the map passes.
+		code string
+	}{
+		{"after the start", map[string]interface{}{"start": int64(1), "end": int64(2)}, ""},
+		{"before the start", map[string]interface{}{"start": int64(3), "end": int64(2)}, CodeGTE},
+		{"missing", map[string]interface{}{"start": int64(1)}, CodeRequired},
+		{"nil rule", map[string]interface{}{"end": int64(2)}, CodeInternal},
+	}
+
+	for _, test := range tests {
+		err := rule.Apply(test.arg)
+		if test.code == "" {
+			if err != nil {
+				t.Errorf("%s: Apply() = %v, want nil", test.name, err)
+			}
+			continue
+		}
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("%s: Apply() = %#v, want a *ValidationError", test.name, err)
+		}
+		if validationErr.Code != test.code || !reflect.DeepEqual(validationErr.Path, []string{"end"}) {
+			t.Errorf("%s: Apply() code = %q, path = %v, want %q, [end]",
+				test.name, validationErr.Code, validationErr.Path, test.code)
+		}
+	}
+}
+
+func TestKeyOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		rule *MapRule
+		arg  map[string]interface{}
+		// code : the code of the failure, or "" if the map passes.
+		code string
+	}{
+		{"null by default", PureMap().Key("x", true, PureString()), map[string]interface{}{"x": nil}, CodeType},
+		{"null by default to a nullable rule", PureMap().Key("x", true, PureString().Nullable()),
+			map[string]interface{}{"x": nil}, ""},
+		{"null as missing", PureMap().Key("x", true, PureString(), NullAsMissing),
+			map[string]interface{}{"x": nil}, CodeRequired},
+		{"null as missing for an optional key", PureMap().Key("x", false, PureString(), NullAsMissing),
+			map[string]interface{}{"x": nil}, ""},
+		{"null allowed", PureMap().Key("x", true, PureString(), NullAllowed), map[string]interface{}{"x": nil}, ""},
+		{"null allowed but missing", PureMap().Key("x", true, PureString(), NullAllowed), map[string]interface{}{}, CodeRequired},
+		{"null denied", PureMap().Key("x", false, PureString().Nullable(), NullDenied),
+			map[string]interface{}{"x": (*string)(nil)}, CodeNotNull},
+		{"null denied but missing", PureMap().Key("x", false, PureString(), NullDenied), map[string]interface{}{}, ""},
+	}
+
+	for _, test := range tests {
+		err := test.rule.Apply(test.arg)
+		if test.code == "" {
+			if err != nil {
+				t.Errorf("%s: Apply() = %v, want nil", test.name, err)
+			}
+			continue
+		}
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Code != test.code {
+			t.Errorf("%s: Apply() = %#v, want code %q", test.name, err, test.code)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package valkyrie
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordPolicy : Represents the requirements checked by a Password rule.
+// Zero valued requirements are not checked.
+type PasswordPolicy struct {
+	// MinLength, MaxLength : the bounds of the length in characters (runes).
+	MinLength, MaxLength int
+	// MinUpper, MinLower, MinDigits, MinSymbols : the minimum count of each character class.
+	// Symbols are all the characters that are not letters or digits.
+	MinUpper, MinLower, MinDigits, MinSymbols int
+	// MinEntropy : the minimum estimated entropy in bits, see PasswordEntropy.
+	MinEntropy float64
+	// MaxRepeated : the maximum run of the same character, such as 3 for "aaa".
+	MaxRepeated int
+	// MaxSequential : the maximum run of ascending or descending characters, such as 4 for "abcd" or "4321".
+	MaxSequential int
+	// Username : the password must not contain it, ignoring case.
+	// To take it from a sibling key, build the rule with MapRule.KeyFunc.
+	Username string
+	// Blocklist : the password must not be one of its entries, ignoring case.
+	Blocklist *PasswordBlocklist
+}
+
+// PasswordBlocklist : Represents a set of forbidden passwords, such as a list of common ones.
+type PasswordBlocklist struct {
+	entries map[string]struct{}
+}
+
+// DefaultPasswordPolicy : Returns a policy requiring at least 8 characters with a lowercase letter,
+// an uppercase letter and a digit, an entropy of 40 bits and no runs longer than 3 characters.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:     8,
+		MaxLength:     128,
+		MinUpper:      1,
+		MinLower:      1,
+		MinDigits:     1,
+		MinEntropy:    40,
+		MaxRepeated:   3,
+		MaxSequential: 3,
+	}
+}
+
+// NewPasswordBlocklist : Creates a blocklist of the given passwords.
+func NewPasswordBlocklist(passwords ...string) *PasswordBlocklist {
+	blocklist := &PasswordBlocklist{entries: make(map[string]struct{}, len(passwords))}
+	for _, password := range passwords {
+		blocklist.entries[strings.ToLower(password)] = struct{}{}
+	}
+	return blocklist
+}
+
+// ReadPasswordBlocklist : Creates a blocklist from a reader with one password per line.
+// Empty lines and lines starting with '#' are skipped.
+func ReadPasswordBlocklist(reader io.Reader) (*PasswordBlocklist, error) {
+	blocklist := &PasswordBlocklist{entries: map[string]struct{}{}}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		blocklist.entries[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return blocklist, nil
+}
+
+// LoadPasswordBlocklist : Creates a blocklist from a local file with one password per line.
+func LoadPasswordBlocklist(path string) (*PasswordBlocklist, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadPasswordBlocklist(file)
+}
+
+// Contains : checks if the password is in the blocklist, ignoring case.
+func (p *PasswordBlocklist) Contains(password string) bool {
+	_, exists := p.entries[strings.ToLower(password)]
+	return exists
+}
+
+// PasswordEntropy : Estimates the entropy of a password in bits as its length multiplied by
+// log2 of the size of the character pool it draws from: 26 for lowercase letters, 26 for uppercase
+// letters, 10 for digits, 33 for ASCII symbols and 100 for any other character.
+func PasswordEntropy(password string) float64 {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < utf8.RuneSelf && unicode.IsPrint(r):
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	pool := 0
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.present {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+	return float64(utf8.RuneCountInString(password)) * math.Log2(float64(pool))
+}
+
+// passwordViolations : checks the password against every requirement of the policy.
+// Each unmet requirement is reported as a ValidationError with its own code, so it can be localized.
+func passwordViolations(password string, policy PasswordPolicy) MultiError {
+	var violations MultiError
+	length := utf8.RuneCountInString(password)
+	if policy.MinLength > 0 && length < policy.MinLength {
+		violations = append(violations, coded(CodePasswordMinLength, Params{"min": policy.MinLength},
+			errPasswordMinLength(policy.MinLength)))
+	}
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		violations = append(violations, coded(CodePasswordMaxLength, Params{"max": policy.MaxLength},
+			errPasswordMaxLength(policy.MaxLength)))
+	}
+
+	var upper, lower, digits, symbols int
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		case unicode.IsDigit(r):
+			digits++
+		case !unicode.IsLetter(r):
+			symbols++
+		}
+	}
+	for _, class := range []struct {
+		code, name string
+		count, min int
+	}{{CodePasswordUpper, "uppercase letters", upper, policy.MinUpper},
+		{CodePasswordLower, "lowercase letters", lower, policy.MinLower},
+		{CodePasswordDigits, "digits", digits, policy.MinDigits},
+		{CodePasswordSymbols, "symbols", symbols, policy.MinSymbols}} {
+		if class.count < class.min {
+			violations = append(violations, coded(class.code, Params{"min": class.min},
+				errPasswordClass(class.min, class.name)))
+		}
+	}
+
+	if policy.MinEntropy > 0 && PasswordEntropy(password) < policy.MinEntropy {
+		violations = append(violations, coded(CodePasswordEntropy, Params{"bits": policy.MinEntropy},
+			errPasswordEntropy(policy.MinEntropy)))
+	}
+	repeated, sequential := passwordRuns(password)
+	if policy.MaxRepeated > 0 && repeated > policy.MaxRepeated {
+		violations = append(violations, coded(CodePasswordRepeated, Params{"max": policy.MaxRepeated},
+			errPasswordRepeated(policy.MaxRepeated)))
+	}
+	if policy.MaxSequential > 0 && sequential > policy.MaxSequential {
+		violations = append(violations, coded(CodePasswordSequential, Params{"max": policy.MaxSequential},
+			errPasswordSequential(policy.MaxSequential)))
+	}
+	if policy.Username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(policy.Username)) {
+		violations = append(violations, coded(CodePasswordUsername, nil, errPasswordUsername()))
+	}
+	if policy.Blocklist != nil && policy.Blocklist.Contains(password) {
+		violations = append(violations, coded(CodePasswordBlocked, nil, errPasswordBlocked()))
+	}
+	return violations
+}
+
+// passwordRuns : the longest run of the same character and the longest run of ascending or
+// descending consecutive characters.
+func passwordRuns(password string) (int, int) {
+	longestRepeated, longestSequential := 0, 0
+	repeated, ascending, descending := 0, 0, 0
+	previous := rune(-1)
+	for _, r := range password {
+		repeated, ascending, descending = nextRun(repeated, r == previous),
+			nextRun(ascending, r == previous+1), nextRun(descending, r == previous-1)
+		if repeated > longestRepeated {
+			longestRepeated = repeated
+		}
+		if ascending > longestSequential {
+			longestSequential = ascending
+		}
+		if descending > longestSequential {
+			longestSequential = descending
+		}
+		previous = r
+	}
+	return longestRepeated, longestSequential
+}
+
+// nextRun : extends the run if it continues, or starts a new run of one character.
+func nextRun(run int, continues bool) int {
+	if continues {
+		return run + 1
+	}
+	return 1
+}
+
+// Password : Creates a StringRule which expects the arg to be a string satisfying the password policy.
+// All unmet requirements are reported together as a MultiError, which errors.As retrieves.
+// Example, with the username taken from a sibling key:
+//
+//	PureMap().KeyFunc("password", true, func(m map[string]interface{}) Rule {
+//		policy := DefaultPasswordPolicy()
+//		policy.Username, _ = m["username"].(string)
+//		return Password(policy)
+//	})
+func Password(policy PasswordPolicy) *StringRule {
+	return PureString().addCheck(CodePassword, nil, func(arg string) error {
+		if violations := passwordViolations(arg, policy); len(violations) > 0 {
+			return violations
+		}
+		return nil
+	})
+}
@@ -0,0 +1,83 @@
+package valkyrie
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPassword(t *testing.T) {
+	blocklist := NewPasswordBlocklist("Password1!")
+	tests := []struct {
+		name     string
+		password string
+		policy   PasswordPolicy
+		want     []string
+	}{
+		{"strong", "correct-Horse-7-battery", DefaultPasswordPolicy(), nil},
+		{"short", "aB3", PasswordPolicy{MinLength: 8}, []string{CodePasswordMinLength}},
+		{"long", "abcdefgh", PasswordPolicy{MaxLength: 4}, []string{CodePasswordMaxLength}},
+		{"length in runes", "ääää", PasswordPolicy{MaxLength: 4}, nil},
+		{
+			name:     "character classes",
+			password: "abc",
+			policy:   PasswordPolicy{MinUpper: 1, MinLower: 4, MinDigits: 1, MinSymbols: 1},
+			want:     []string{CodePasswordUpper, CodePasswordLower, CodePasswordDigits, CodePasswordSymbols},
+		},
+		{"entropy", "aaaaaa", PasswordPolicy{MinEntropy: 40}, []string{CodePasswordEntropy}},
+		{"repeated", "xaaaay", PasswordPolicy{MaxRepeated: 3}, []string{CodePasswordRepeated}},
+		{"ascending", "x1234y", PasswordPolicy{MaxSequential: 3}, []string{CodePasswordSequential}},
+		{"descending", "xdcbay", PasswordPolicy{MaxSequential: 3}, []string{CodePasswordSequential}},
+		{"runs within the limits", "xaaa123y", PasswordPolicy{MaxRepeated: 3, MaxSequential: 3}, nil},
+		{"username", "my-JaneDoe-pw", PasswordPolicy{Username: "janedoe"}, []string{CodePasswordUsername}},
+		{"blocked", "PASSWORD1!", PasswordPolicy{Blocklist: blocklist}, []string{CodePasswordBlocked}},
+	}
+
+	for _, test := range tests {
+		err := Password(test.policy).Apply(test.password)
+		var violations MultiError
+		if err != nil && !errors.As(err, &violations) {
+			t.Fatalf("%s: Apply() = %#v, want a MultiError", test.name, err)
+		}
+		var got []string
+		for _, violation := range violations {
+			got = append(got, violation.(*ValidationError).Code)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Apply(%q) codes = %v, want %v", test.name, test.password, got, test.want)
+		}
+	}
+}
+
+func TestPasswordEntropy(t *testing.T) {
+	tests := []struct {
+		password string
+		want     float64
+	}{
+		{"", 0},
+		{"abcd", 4 * math.Log2(26)},
+		{"aB3", 3 * math.Log2(62)},
+		{"Tr0ub4dor&3", 11 * math.Log2(95)},
+		{"пароль", 6 * math.Log2(100)},
+	}
+
+	for _, test := range tests {
+		if got := PasswordEntropy(test.password); math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("PasswordEntropy(%q) = %v, want %v", test.password, got, test.want)
+		}
+	}
+}
+
+func TestReadPasswordBlocklist(t *testing.T) {
+	blocklist, err := ReadPasswordBlocklist(strings.NewReader("# common\n123456\n\n  Qwerty  \n"))
+	if err != nil {
+		t.Fatalf("ReadPasswordBlocklist() error = %v", err)
+	}
+	for password, want := range map[string]bool{"123456": true, "QWERTY": true, "# common": false, "": false} {
+		if got := blocklist.Contains(password); got != want {
+			t.Errorf("Contains(%q) = %v, want %v", password, got, want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package valkyrie
+
+import "strings"
+
+// phoneRegionLookup : the numbering plan of every region, keyed by the region code.
+var phoneRegionLookup = func() map[string]phoneRegion {
+	lookup := make(map[string]phoneRegion, len(phoneRegions))
+	for _, region := range phoneRegions {
+		lookup[region.region] = region
+	}
+	return lookup
+}()
+
+// callingCodeLookup : the numbering plans sharing a country calling code, keyed by the calling code.
+var callingCodeLookup = func() map[string][]phoneRegion {
+	lookup := map[string][]phoneRegion{}
+	for _, region := range phoneRegions {
+		lookup[region.callingCode] = append(lookup[region.callingCode], region)
+	}
+	return lookup
+}()
+
+// phoneSeparators : the formatting characters allowed between the digits of a phone number.
+const phoneSeparators = " -.()"
+
+// splitCallingCode : splits the digits of an international number into the calling code and the
+// national significant number. Calling codes are prefix-free, so the first match is the only one.
+func splitCallingCode(digits string) (string, string, bool) {
+	for length := 1; length <= 3 && length < len(digits); length++ {
+		if _, exists := callingCodeLookup[digits[:length]]; exists {
+			return digits[:length], digits[length:], true
+		}
+	}
+	return "", "", false
+}
+
+// accepts : checks the national significant number against the length rules of the region.
+// Only Italian numbers keep their leading zero after the calling code.
+func (p phoneRegion) accepts(nsn string) bool {
+	if len(nsn) == 0 || (nsn[0] == '0' && p.callingCode != "39") {
+		return false
+	}
+	return len(nsn) >= p.minLength && len(nsn) <= p.maxLength
+}
+
+// isValidNationalNumber : checks the national significant number against the regions sharing the calling code.
+func isValidNationalNumber(callingCode string, nsn string) bool {
+	for _, region := range callingCodeLookup[callingCode] {
+		if region.accepts(nsn) {
+			return true
+		}
+	}
+	return false
+}
+
+// isE164 : checks if the string is an E.164 number: '+' followed by at most 15 digits, without any
+// formatting, carrying a known calling code and a national number of a valid length.
+func isE164(str string) bool {
+	if len(str) < 3 || len(str) > 16 || str[0] != '+' || str[1] == '0' || !isNumeric(str[1:]) {
+		return false
+	}
+	callingCode, nsn, ok := splitCallingCode(str[1:])
+	return ok && isValidNationalNumber(callingCode, nsn)
+}
+
+// isPhoneNumber : checks if the string is a phone number, either in the international format with a
+// leading '+', or in the national format of the default region. Formatting characters are ignored.
+func isPhoneNumber(str string, defaultRegion string) bool {
+	digits := make([]byte, 0, len(str))
+	international := len(str) > 0 && str[0] == '+'
+	if international {
+		str = str[1:]
+	}
+	for i := 0; i < len(str); i++ {
+		switch c := str[i]; {
+		case isDigit(c):
+			digits = append(digits, c)
+		case strings.IndexByte(phoneSeparators, c) < 0:
+			return false
+		}
+	}
+
+	if international {
+		if len(digits) > 15 {
+			return false
+		}
+		callingCode, nsn, ok := splitCallingCode(string(digits))
+		return ok && isValidNationalNumber(callingCode, nsn)
+	}
+
+	region, exists := phoneRegionLookup[defaultRegion]
+	if !exists {
+		return false
+	}
+	nsn := string(digits)
+	if region.trunkPrefix != "" {
+		nsn = strings.TrimPrefix(nsn, region.trunkPrefix)
+	}
+	return region.accepts(nsn)
+}
+
+// E164 : Adds an E.164 phone number check on the string.
+// Example: "+14155552671"
+func (s *StringRule) E164() *StringRule {
+	s.addCheck(CodeE164, nil, func(arg string) error {
+		if !isE164(arg) {
+			return errStringE164()
+		}
+		return nil
+	})
+	return s
+}
+
+// PhoneNumber : Adds a phone number check on the string.
+// Numbers starting with '+' are validated against the plan of their calling code, others against the
+// plan of the default region (ISO 3166-1 alpha-2), after removing its national trunk prefix.
+// Spaces, hyphens, dots and parentheses are allowed as formatting.
+// Example: PhoneNumber("DE") accepts "+49 30 123456" and "030 123456".
+func (s *StringRule) PhoneNumber(defaultRegion string) *StringRule {
+	s.addCheck(CodePhoneNumber, Params{"region": defaultRegion}, func(arg string) error {
+		if !isPhoneNumber(arg, defaultRegion) {
+			return errStringPhone(defaultRegion)
+		}
+		return nil
+	})
+	return s
+}
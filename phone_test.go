@@ -0,0 +1,54 @@
+package valkyrie
+
+import "testing"
+
+func TestE164(t *testing.T) {
+	tests := []struct {
+		number string
+		want   bool
+	}{
+		{"+14155552671", true},
+		{"+493012345678", true},
+		{"+390612345678", true},
+		{"+4930123456789012", false},
+		{"+1 415 555 2671", false},
+		{"14155552671", false},
+		{"+0123456789", false},
+		{"+1415555267", false},
+		{"+", false},
+	}
+
+	for _, test := range tests {
+		if got := PureString().E164().Apply(test.number) == nil; got != test.want {
+			t.Errorf("E164().Apply(%q) passed = %v, want %v", test.number, got, test.want)
+		}
+	}
+}
+
+func TestPhoneNumber(t *testing.T) {
+	tests := []struct {
+		number string
+		region string
+		want   bool
+	}{
+		{"+49 30 123456", "DE", true},
+		{"030 123456", "DE", true},
+		{"030/123456", "DE", false},
+		{"(415) 555-2671", "US", true},
+		{"1 415 555 2671", "US", true},
+		{"415 555 267", "US", false},
+		{"+44 20 7946 0958", "US", true},
+		{"+44 020 7946 0958", "US", false},
+		{"06 1234 5678", "IT", true},
+		{"+39 06 1234 5678", "", true},
+		{"030 123456", "", false},
+		{"030 123456", "XX", false},
+		{"", "DE", false},
+	}
+
+	for _, test := range tests {
+		if got := PureString().PhoneNumber(test.region).Apply(test.number) == nil; got != test.want {
+			t.Errorf("PhoneNumber(%q).Apply(%q) passed = %v, want %v", test.region, test.number, got, test.want)
+		}
+	}
+}
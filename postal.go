@@ -0,0 +1,47 @@
+package valkyrie
+
+import (
+	"regexp"
+	"sync"
+)
+
+// postalCodeRegexes : the compiled postal code formats, keyed by the region code.
+// The formats are compiled on first use.
+var postalCodeRegexes sync.Map
+
+// postalCodeRegex : returns the compiled postal code format of the region, or nil if the region has none.
+func postalCodeRegex(country string) *regexp.Regexp {
+	if reg, exists := postalCodeRegexes.Load(country); exists {
+		return reg.(*regexp.Regexp)
+	}
+	format, exists := postalCodeFormats[country]
+	if !exists {
+		return nil
+	}
+	reg := regexp.MustCompile("^(?:" + format + ")$")
+	postalCodeRegexes.Store(country, reg)
+	return reg
+}
+
+// PostalCode : Adds a check that the string is a postal code of the given country (ISO 3166-1 alpha-2).
+// Letters must be uppercase. Countries without a known postal code format fail the check.
+//
+// When the country comes from a sibling key, the rule can be built per map using MapRule.KeyFunc.
+// Example:
+//
+//	PureMap().
+//		Key("country", true, PureString().CountryCode(CountryAlpha2)).
+//		KeyFunc("zip", true, func(m map[string]interface{}) Rule {
+//			country, _ := m["country"].(string)
+//			return PureString().PostalCode(country)
+//		})
+func (s *StringRule) PostalCode(country string) *StringRule {
+	s.addCheck(CodePostalCode, Params{"country": country}, func(arg string) error {
+		reg := postalCodeRegex(country)
+		if reg == nil || !reg.MatchString(arg) {
+			return errStringPostalCode(country)
+		}
+		return nil
+	})
+	return s
+}
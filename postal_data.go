@@ -0,0 +1,138 @@
+package valkyrie
+
+// postalCodeFormats : the postal code format of each region, keyed by its ISO 3166-1 alpha-2 code.
+// Regions without a postal code system are absent. Letters are uppercase.
+var postalCodeFormats = map[string]string{
+	"AD": `AD[1-7]0\d`,
+	"AL": `\d{4}`,
+	"AM": `(?:37)?\d{4}`,
+	"AR": `(?:[A-HJ-NP-Z])?\d{4}(?:[A-Z]{3})?`,
+	"AS": `96799(?:-\d{4})?`,
+	"AT": `\d{4}`,
+	"AU": `\d{4}`,
+	"AX": `22\d{3}`,
+	"AZ": `\d{4}`,
+	"BA": `\d{5}`,
+	"BD": `\d{4}`,
+	"BE": `\d{4}`,
+	"BG": `\d{4}`,
+	"BH": `(?:1[0-2]|[1-9])\d{2}`,
+	"BN": `[A-Z]{2} ?\d{4}`,
+	"BR": `\d{5}-?\d{3}`,
+	"BY": `\d{6}`,
+	"CA": `[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] ?\d[ABCEGHJ-NPRSTV-Z]\d`,
+	"CH": `\d{4}`,
+	"CL": `\d{7}`,
+	"CN": `\d{6}`,
+	"CO": `\d{6}`,
+	"CR": `\d{4,5}|\d{3}-\d{4}`,
+	"CU": `\d{5}`,
+	"CY": `\d{4}`,
+	"CZ": `\d{3} ?\d{2}`,
+	"DE": `\d{5}`,
+	"DK": `\d{4}`,
+	"DO": `\d{5}`,
+	"DZ": `\d{5}`,
+	"EC": `\d{6}`,
+	"EE": `\d{5}`,
+	"EG": `\d{5}`,
+	"ES": `\d{5}`,
+	"ET": `\d{4}`,
+	"FI": `\d{5}`,
+	"FO": `\d{3}`,
+	"FR": `\d{2} ?\d{3}`,
+	"GB": `GIR ?0AA|(?:[A-PR-UWYZ]\d\d?|[A-PR-UWYZ][A-HK-Y]\d\d?|[A-PR-UWYZ]\d[A-HJKPSTUW]|[A-PR-UWYZ][A-HK-Y]\d[ABEHMNPRVWXY]) ?\d[ABD-HJLNP-UW-Z]{2}`,
+	"GE": `\d{4}`,
+	"GF": `9[78]3\d{2}`,
+	"GG": `GY\d[\dA-Z]? ?\d[ABD-HJLN-UW-Z]{2}`,
+	"GL": `39\d{2}`,
+	"GP": `9[78][01]\d{2}`,
+	"GR": `\d{3} ?\d{2}`,
+	"GT": `\d{5}`,
+	"GU": `969(?:[12]\d|3[12])(?:-\d{4})?`,
+	"HR": `\d{5}`,
+	"HT": `\d{4}`,
+	"HU": `\d{4}`,
+	"ID": `\d{5}`,
+	"IE": `[\dA-Z]{3} ?[\dA-Z]{4}`,
+	"IL": `\d{5}(?:\d{2})?`,
+	"IM": `IM\d[\dA-Z]? ?\d[ABD-HJLN-UW-Z]{2}`,
+	"IN": `\d{6}`,
+	"IQ": `\d{5}`,
+	"IR": `\d{5}-?\d{5}`,
+	"IS": `\d{3}`,
+	"IT": `\d{5}`,
+	"JE": `JE\d[\dA-Z]? ?\d[ABD-HJLN-UW-Z]{2}`,
+	"JO": `\d{5}`,
+	"JP": `\d{3}-?\d{4}`,
+	"KE": `\d{5}`,
+	"KG": `\d{6}`,
+	"KH": `\d{5,6}`,
+	"KR": `\d{5}`,
+	"KW": `\d{5}`,
+	"KZ": `\d{6}`,
+	"LA": `\d{5}`,
+	"LB": `(?:\d{4})(?: ?(?:\d{4}))?`,
+	"LI": `948[5-9]|949[0-8]`,
+	"LK": `\d{5}`,
+	"LT": `(?:LT-)?\d{5}`,
+	"LU": `(?:L-)?\d{4}`,
+	"LV": `LV-\d{4}`,
+	"MA": `\d{5}`,
+	"MC": `980\d{2}`,
+	"MD": `(?:MD-?)?\d{4}`,
+	"ME": `8\d{4}`,
+	"MG": `\d{3}`,
+	"MK": `\d{4}`,
+	"MN": `\d{5}`,
+	"MQ": `9[78]2\d{2}`,
+	"MT": `[A-Z]{3} ?\d{2,4}`,
+	"MX": `\d{5}`,
+	"MY": `\d{5}`,
+	"NC": `988\d{2}`,
+	"NG": `\d{6}`,
+	"NI": `\d{5}`,
+	"NL": `\d{4} ?[A-Z]{2}`,
+	"NO": `\d{4}`,
+	"NP": `\d{5}`,
+	"NZ": `\d{4}`,
+	"OM": `(?:PC )?\d{3}`,
+	"PE": `\d{5}`,
+	"PF": `987\d{2}`,
+	"PH": `\d{4}`,
+	"PK": `\d{5}`,
+	"PL": `\d{2}-\d{3}`,
+	"PM": `9[78]5\d{2}`,
+	"PR": `00[679]\d{2}(?:-\d{4})?`,
+	"PT": `\d{4}-\d{3}`,
+	"PY": `\d{4}`,
+	"RE": `9[78]4\d{2}`,
+	"RO": `\d{6}`,
+	"RS": `\d{5,6}`,
+	"RU": `\d{6}`,
+	"SA": `\d{5}(?:-\d{4})?`,
+	"SE": `\d{3} ?\d{2}`,
+	"SG": `\d{6}`,
+	"SI": `\d{4}`,
+	"SK": `\d{3} ?\d{2}`,
+	"SM": `4789\d`,
+	"SN": `\d{5}`,
+	"SV": `CP [1-3][1-7][0-2]\d`,
+	"TH": `\d{5}`,
+	"TJ": `\d{6}`,
+	"TM": `\d{6}`,
+	"TN": `\d{4}`,
+	"TR": `\d{5}`,
+	"TW": `\d{3}(?:\d{2,3})?`,
+	"UA": `\d{5}`,
+	"US": `\d{5}(?:-\d{4})?`,
+	"UY": `\d{5}`,
+	"UZ": `\d{6}`,
+	"VA": `00120`,
+	"VE": `\d{4}`,
+	"VI": `008(?:(?:[0-4]\d)|(?:5[01]))(?:-\d{4})?`,
+	"VN": `\d{6}`,
+	"YT": `976\d{2}`,
+	"ZA": `\d{4}`,
+	"ZM": `\d{5}`,
+}
@@ -0,0 +1,31 @@
+package valkyrie
+
+import "testing"
+
+func TestPostalCode(t *testing.T) {
+	tests := []struct {
+		code    string
+		country string
+		want    bool
+	}{
+		{"10115", "DE", true},
+		{"1011", "DE", false},
+		{"94105", "US", true},
+		{"94105-1234", "US", true},
+		{"94105-12", "US", false},
+		{"SW1A 1AA", "GB", true},
+		{"SW1A1AA", "GB", true},
+		{"sw1a 1aa", "GB", false},
+		{"1012 AB", "NL", true},
+		{"K1A 0B1", "CA", true},
+		{"100-0001", "JP", true},
+		{"10115", "XX", false},
+		{" 10115", "DE", false},
+	}
+
+	for _, test := range tests {
+		if got := PureString().PostalCode(test.country).Apply(test.code) == nil; got != test.want {
+			t.Errorf("PostalCode(%q).Apply(%q) passed = %v, want %v", test.country, test.code, got, test.want)
+		}
+	}
+}
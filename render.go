@@ -0,0 +1,179 @@
+package valkyrie
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ProblemContentType : The media type of RFC 7807 problem details documents.
+const ProblemContentType = "application/problem+json"
+
+// Problem : Represents an RFC 7807 problem details document describing a validation failure.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params"`
+}
+
+// InvalidParam : Represents a failed value in the invalid-params extension of a Problem.
+type InvalidParam struct {
+	// Name : the path of the failing value joined with dots, or "value" if it is the root.
+	Name string `json:"name"`
+	// Reason : the message of the failure.
+	Reason string `json:"reason"`
+	// Code : the stable code of the failure, if it is a ValidationError.
+	Code string `json:"code,omitempty"`
+	// Params : the params of the failed check.
+	Params Params `json:"params,omitempty"`
+}
+
+// NewProblem : Creates a 400 Bad Request problem listing the failures of the error.
+func NewProblem(err error) *Problem {
+	return &Problem{
+		Type:          "about:blank",
+		Title:         http.StatusText(http.StatusBadRequest),
+		Status:        http.StatusBadRequest,
+		Detail:        "The request did not pass validation.",
+		InvalidParams: InvalidParams(err),
+	}
+}
+
+// InvalidParams : Lists the failures of the error, expanding MultiErrors into their elements.
+// The elements of a ValidationError wrapping a MultiError, such as the unmet requirements of Password,
+// are listed under its name with their own codes and params; plain errors among them take its code.
+// Errors other than ValidationErrors are named "value" and have no code.
+func InvalidParams(err error) []InvalidParam {
+	params := []InvalidParam{}
+	switch typed := err.(type) {
+	case nil:
+	case MultiError:
+		for _, err := range typed {
+			params = append(params, InvalidParams(err)...)
+		}
+	case *ValidationError:
+		multi, isMulti := typed.Err.(MultiError)
+		if !isMulti || typed.Message != "" {
+			return append(params, InvalidParam{
+				Name: typed.Field(), Reason: typed.Error(), Code: typed.Code, Params: typed.Params,
+			})
+		}
+		for _, err := range multi {
+			for _, param := range InvalidParams(err) {
+				if param.Code == "" {
+					param.Code, param.Params = typed.Code, typed.Params
+				}
+				param.Name = joinFieldNames(typed.Field(), param.Name)
+				params = append(params, param)
+			}
+		}
+	default:
+		params = append(params, InvalidParam{Name: "value", Reason: err.Error()})
+	}
+	return params
+}
+
+// RenderProblem : Renders the error as an application/problem+json document.
+func RenderProblem(err error) ([]byte, error) {
+	return marshalUnescaped(NewProblem(err))
+}
+
+// FieldMessages : Groups the messages of the failures of the error by field name.
+func FieldMessages(err error) map[string][]string {
+	messages := map[string][]string{}
+	for _, param := range InvalidParams(err) {
+		messages[param.Name] = append(messages[param.Name], param.Reason)
+	}
+	return messages
+}
+
+// RenderFieldMap : Renders the error as a JSON object of messages keyed by field name.
+// Example: {"user.name": ["user.name must be at least 3 characters long"]}
+func RenderFieldMap(err error) ([]byte, error) {
+	return marshalUnescaped(FieldMessages(err))
+}
+
+// RenderTextTree : Renders the error as an indented tree of keys and messages, suitable for CLI output.
+// Example:
+//
+//	user
+//	  name
+//	    - value should follow: type: string && length >= 3
+func RenderTextTree(err error) string {
+	root := &textTreeNode{}
+	for _, param := range InvalidParams(err) {
+		node := root
+		if param.Name != "value" {
+			for _, key := range strings.Split(param.Name, ".") {
+				node = node.child(key)
+			}
+		}
+		node.messages = append(node.messages, param.Reason)
+	}
+
+	var builder strings.Builder
+	root.render(&builder, 0)
+	return builder.String()
+}
+
+// joinFieldNames : joins the name of a failure with the name of the failure wrapping it,
+// where "value" stands for the root.
+func joinFieldNames(outer string, inner string) string {
+	switch {
+	case inner == "value":
+		return outer
+	case outer == "value":
+		return inner
+	}
+	return outer + "." + inner
+}
+
+// marshalUnescaped : encodes the value as JSON without escaping characters such as '&' and '>',
+// which are common in the messages.
+func marshalUnescaped(value interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// textTreeNode : a key of the text tree, along with the messages of its failures.
+type textTreeNode struct {
+	messages []string
+	children map[string]*textTreeNode
+}
+
+func (t *textTreeNode) child(key string) *textTreeNode {
+	if t.children == nil {
+		t.children = map[string]*textTreeNode{}
+	}
+	if _, exists := t.children[key]; !exists {
+		t.children[key] = &textTreeNode{}
+	}
+	return t.children[key]
+}
+
+func (t *textTreeNode) render(builder *strings.Builder, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, message := range t.messages {
+		builder.WriteString(indent + "- " + message + "\n")
+	}
+
+	keys := make([]string, 0, len(t.children))
+	for key := range t.children {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		builder.WriteString(indent + key + "\n")
+		t.children[key].render(builder, depth+1)
+	}
+}
@@ -0,0 +1,86 @@
+package valkyrie
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInvalidParams(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []InvalidParam
+	}{
+		{name: "nil", err: nil, want: []InvalidParam{}},
+		{
+			name: "plain error",
+			err:  errors.New("bad"),
+			want: []InvalidParam{{Name: "value", Reason: "bad"}},
+		},
+		{
+			name: "keyed validation error",
+			err:  PureMap().Key("age", true, PureInt().GTE(18)).Apply(map[string]interface{}{"age": int64(3)}),
+			want: []InvalidParam{{
+				Name: "age", Reason: errIntGTE(18).Error(), Code: CodeGTE, Params: Params{"min": int64(18)},
+			}},
+		},
+		{
+			name: "all errors",
+			err: PureMap().AllErrors().Key("a", true, PureString()).Key("b", true, PureString()).
+				Apply(map[string]interface{}{}),
+			want: []InvalidParam{
+				{Name: "a", Reason: errMapKeyMissing("a").Error(), Code: CodeRequired, Params: Params{"key": "a"}},
+				{Name: "b", Reason: errMapKeyMissing("b").Error(), Code: CodeRequired, Params: Params{"key": "b"}},
+			},
+		},
+		{
+			name: "password requirements keep their codes",
+			err: PureMap().Key("pw", true, Password(PasswordPolicy{MinLength: 8, MinDigits: 1})).
+				Apply(map[string]interface{}{"pw": "abc"}),
+			want: []InvalidParam{
+				{Name: "pw", Reason: errPasswordMinLength(8).Error(), Code: CodePasswordMinLength, Params: Params{"min": 8}},
+				{Name: "pw", Reason: errPasswordClass(1, "digits").Error(), Code: CodePasswordDigits, Params: Params{"min": 1}},
+			},
+		},
+		{
+			name: "plain errors in a wrapped multi error take the outer code",
+			err:  &ValidationError{Code: CodeCustom, Path: []string{"x"}, Err: MultiError{errors.New("one")}},
+			want: []InvalidParam{{Name: "x", Reason: "one", Code: CodeCustom}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := InvalidParams(test.err); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("InvalidParams() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRenderFieldMap(t *testing.T) {
+	err := PureMap().AllErrors().
+		Key("user", true, PureMap().Key("name", true, PureString().LenGTE(3).Msg("{field} is too short"))).
+		Key("tags", true, PureString()).
+		Apply(map[string]interface{}{"user": map[string]interface{}{"name": "a"}, "tags": "x & y"})
+
+	got, renderErr := RenderFieldMap(err)
+	if renderErr != nil {
+		t.Fatalf("RenderFieldMap() error = %v", renderErr)
+	}
+	if want := `{"user.name":["user.name is too short"]}`; string(got) != want {
+		t.Errorf("RenderFieldMap() = %s, want %s", got, want)
+	}
+}
+
+func TestRenderTextTree(t *testing.T) {
+	err := MultiError{
+		&ValidationError{Path: []string{"user", "name"}, Err: errors.New("too short")},
+		errors.New("invalid"),
+	}
+	want := "- invalid\nuser\n  name\n    - too short\n"
+	if got := RenderTextTree(err); got != want {
+		t.Errorf("RenderTextTree() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,67 @@
+package valkyrie
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestNullability(t *testing.T) {
+	var nilString *string
+	tests := []struct {
+		name                    string
+		plain, nullable, denied Rule
+	}{
+		{"string", PureString(), PureString().Nullable(), PureString().NotNull()},
+		{"int", PureInt(), PureInt().Nullable(), PureInt().NotNull()},
+		{"float", PureFloat(), PureFloat().Nullable(), PureFloat().NotNull()},
+		{"bool", PureBool(), PureBool().Nullable(), PureBool().NotNull()},
+		{"map", PureMap(), PureMap().Nullable(), PureMap().NotNull()},
+		{"slice", PureSlice(), PureSlice().Nullable(), PureSlice().NotNull()},
+		{"form", PureForm(), PureForm().Nullable(), PureForm().NotNull()},
+		{"file", PureFile(), PureFile().Nullable(), PureFile().NotNull()},
+		{"geojson", GeoJSON(), GeoJSON().Nullable(), GeoJSON().NotNull()},
+	}
+
+	for _, test := range tests {
+		for _, null := range []interface{}{nil, nilString} {
+			if err := test.plain.Apply(null); err == nil {
+				t.Errorf("%s: Apply(%#v) = nil, want an error", test.name, null)
+			}
+			if err := test.nullable.Apply(null); err != nil {
+				t.Errorf("%s: Nullable().Apply(%#v) = %v, want nil", test.name, null, err)
+			}
+			var validationErr *ValidationError
+			if err := test.denied.Apply(null); !errors.As(err, &validationErr) || validationErr.Code != CodeNotNull {
+				t.Errorf("%s: NotNull().Apply(%#v) = %#v, want code %q", test.name, null, err, CodeNotNull)
+			}
+		}
+	}
+}
+
+func TestPointers(t *testing.T) {
+	str, integer, float, boolean := "abc", int64(5), 1.5, true
+	pointer := &integer
+	form := url.Values{"a": {"1"}}
+	tests := []struct {
+		name string
+		rule Rule
+		arg  interface{}
+		want bool
+	}{
+		{"string", PureString().LenGTE(3), &str, true},
+		{"int", PureInt().GTE(5), &integer, true},
+		{"int of any depth", PureInt().GTE(5), &pointer, true},
+		{"int failing", PureInt().GTE(6), &pointer, false},
+		{"float", PureFloat().LTE(2), &float, true},
+		{"bool", PureBool(), &boolean, true},
+		{"form", PureForm(), &form, true},
+		{"wrong type", PureString(), &integer, false},
+	}
+
+	for _, test := range tests {
+		if got := test.rule.Apply(test.arg) == nil; got != test.want {
+			t.Errorf("%s: Apply(%T) passed = %v, want %v", test.name, test.arg, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,477 @@
+package valkyrie
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semVersion : a version as per Semantic Versioning 2.0.0. The build metadata is validated but not
+// kept since it does not take part in precedence.
+type semVersion struct {
+	major, minor, patch uint64
+	prerelease          []string
+}
+
+// semComparator : a primitive comparison of a range, such as ">=1.2.0".
+type semComparator struct {
+	op      string
+	version semVersion
+	// synthetic : whether the "-0" prerelease of the version was added by desugaring, as in ">1.2" into
+	// ">=1.3.0-0". Such prereleases do not let the prereleases of their version satisfy the range.
+	synthetic bool
+}
+
+// semRange : a union of intersections of comparators, such as ">=1.2.0 <2.0.0-0 || >=3.1.0 <4.0.0-0".
+type semRange [][]semComparator
+
+// semPartial : a possibly incomplete version of a range, such as "1.2", "1.x" or "*".
+type semPartial struct {
+	major, minor, patch uint64
+	prerelease          []string
+	// parts : the count of the leading parts that are present and not wildcards.
+	parts int
+}
+
+// parseSemVer : parses a version string as per the SemVer 2.0.0 grammar.
+func parseSemVer(str string) (semVersion, bool) {
+	if i := strings.IndexByte(str, '+'); i >= 0 {
+		if !isSemVerIdentifiers(str[i+1:], false) {
+			return semVersion{}, false
+		}
+		str = str[:i]
+	}
+	var version semVersion
+	if i := strings.IndexByte(str, '-'); i >= 0 {
+		if !isSemVerIdentifiers(str[i+1:], true) {
+			return semVersion{}, false
+		}
+		version.prerelease = strings.Split(str[i+1:], ".")
+		str = str[:i]
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) != 3 {
+		return semVersion{}, false
+	}
+	numbers := [3]*uint64{&version.major, &version.minor, &version.patch}
+	for i, part := range parts {
+		number, ok := parseSemVerNumber(part)
+		if !ok {
+			return semVersion{}, false
+		}
+		*numbers[i] = number
+	}
+	return version, true
+}
+
+// parseSemVerNumber : parses a numeric identifier, which must not have leading zeros.
+func parseSemVerNumber(str string) (uint64, bool) {
+	if str == "" || !isNumeric(str) || (len(str) > 1 && str[0] == '0') {
+		return 0, false
+	}
+	number, err := strconv.ParseUint(str, 10, 64)
+	return number, err == nil
+}
+
+// isSemVerIdentifiers : checks dot separated identifiers of a prerelease or of build metadata.
+// Only prerelease numeric identifiers are checked for leading zeros.
+func isSemVerIdentifiers(str string, prerelease bool) bool {
+	for _, identifier := range strings.Split(str, ".") {
+		if identifier == "" {
+			return false
+		}
+		for i := 0; i < len(identifier); i++ {
+			if c := identifier[i]; c != '-' && !isDigit(c) && !isAlpha(identifier[i:i+1]) {
+				return false
+			}
+		}
+		if prerelease && isNumeric(identifier) && len(identifier) > 1 && identifier[0] == '0' {
+			return false
+		}
+	}
+	return true
+}
+
+// compare : returns -1, 0 or +1 as per the SemVer precedence of the two versions.
+func (v semVersion) compare(other semVersion) int {
+	for _, pair := range [][2]uint64{{v.major, other.major}, {v.minor, other.minor}, {v.patch, other.patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A version without a prerelease has a higher precedence than one with it.
+	switch {
+	case len(v.prerelease) == 0 && len(other.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(other.prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.prerelease) && i < len(other.prerelease); i++ {
+		if c := compareSemVerIdentifiers(v.prerelease[i], other.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.prerelease) < len(other.prerelease):
+		return -1
+	case len(v.prerelease) > len(other.prerelease):
+		return 1
+	}
+	return 0
+}
+
+// compareSemVerIdentifiers : numeric identifiers compare numerically and have lower precedence than
+// alphanumeric ones, which compare in ASCII order.
+func compareSemVerIdentifiers(a, b string) int {
+	aNumeric, bNumeric := isNumeric(a), isNumeric(b)
+	switch {
+	case aNumeric && bNumeric:
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func (v semVersion) sameTriple(other semVersion) bool {
+	return v.major == other.major && v.minor == other.minor && v.patch == other.patch
+}
+
+// parseSemRange : parses a range expression made of comparator sets separated by "||".
+// The comparators of a set are separated by whitespace and are either primitives (<, <=, >, >=, =),
+// tilde (~) or caret (^) ranges, x-ranges (1.x, 1.2.*, 1) or hyphen ranges (1.2 - 2.3.4).
+func parseSemRange(str string) (semRange, bool) {
+	var sets semRange
+	for _, setStr := range strings.Split(str, "||") {
+		set, ok := parseSemComparatorSet(setStr)
+		if !ok {
+			return nil, false
+		}
+		sets = append(sets, set)
+	}
+	return sets, true
+}
+
+// parseSemComparatorSet : parses the whitespace separated comparators of a set into primitives.
+// Empty sets are rejected, use "*" to match any version.
+func parseSemComparatorSet(str string) ([]semComparator, bool) {
+	fields := strings.Fields(str)
+	// An operator may be separated from its version by whitespace, such as ">= 1.2".
+	var tokens []string
+	for i := 0; i < len(fields); i++ {
+		if strings.Trim(fields[i], "<>=~^") == "" && fields[i] != "-" && i+1 < len(fields) {
+			tokens = append(tokens, fields[i]+fields[i+1])
+			i++
+			continue
+		}
+		tokens = append(tokens, fields[i])
+	}
+
+	if len(tokens) == 0 {
+		return nil, false
+	}
+	if len(tokens) == 3 && tokens[1] == "-" {
+		low, ok1 := parseSemPartial(tokens[0])
+		high, ok2 := parseSemPartial(tokens[2])
+		if !ok1 || !ok2 {
+			return nil, false
+		}
+		return append(low.lowerBound(">="), high.upperBound("<=")...), true
+	}
+
+	set := []semComparator{}
+	for _, token := range tokens {
+		comparators, ok := parseSemComparator(token)
+		if !ok {
+			return nil, false
+		}
+		set = append(set, comparators...)
+	}
+	return set, true
+}
+
+// parseSemComparator : desugars a single comparator into primitives.
+func parseSemComparator(token string) ([]semComparator, bool) {
+	op := token[:len(token)-len(strings.TrimLeft(token, "<>=~^"))]
+	switch op {
+	case "", "=", "<", "<=", ">", ">=", "~", "^":
+	default:
+		return nil, false
+	}
+	partial, ok := parseSemPartial(token[len(op):])
+	if !ok {
+		return nil, false
+	}
+
+	switch op {
+	case "", "=":
+		if partial.parts == 3 {
+			return []semComparator{{"=", partial.version(), false}}, true
+		}
+		return append(partial.lowerBound(">="), partial.upperBound("<=")...), true
+	case ">", ">=":
+		return partial.lowerBound(op), true
+	case "<", "<=":
+		return partial.upperBound(op), true
+	case "~":
+		lower := partial.lowerBound(">=")
+		if partial.parts == 3 {
+			partial.parts = 2
+		}
+		return append(lower, partial.upperBound("<=")...), true
+	default: // "^"
+		if partial.parts == 0 {
+			return nil, true
+		}
+		lower := partial.lowerBound(">=")
+		// The upper bound increments the first non-zero part, or the last present part.
+		switch {
+		case partial.major > 0 || partial.parts <= 1:
+			partial.parts = 1
+		case partial.minor > 0 || partial.parts == 2:
+			partial.parts = 2
+		}
+		return append(lower, partial.upperBound("<=")...), true
+	}
+}
+
+// parseSemPartial : parses a version whose trailing parts may be missing or wildcards (x, X, *).
+func parseSemPartial(str string) (semPartial, bool) {
+	var partial semPartial
+	if str == "" {
+		return semPartial{}, false
+	}
+	if i := strings.IndexByte(str, '+'); i >= 0 {
+		if !isSemVerIdentifiers(str[i+1:], false) {
+			return semPartial{}, false
+		}
+		str = str[:i]
+	}
+	if i := strings.IndexByte(str, '-'); i >= 0 {
+		if !isSemVerIdentifiers(str[i+1:], true) {
+			return semPartial{}, false
+		}
+		partial.prerelease = strings.Split(str[i+1:], ".")
+		str = str[:i]
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) > 3 {
+		return semPartial{}, false
+	}
+	numbers := [3]*uint64{&partial.major, &partial.minor, &partial.patch}
+	wildcard := false
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			wildcard = true
+			continue
+		}
+		number, ok := parseSemVerNumber(part)
+		if !ok || wildcard {
+			return semPartial{}, false
+		}
+		*numbers[i] = number
+		partial.parts++
+	}
+	// A prerelease is meaningful only on a complete version.
+	if partial.prerelease != nil && partial.parts != 3 {
+		return semPartial{}, false
+	}
+	return partial, true
+}
+
+// version : the complete version of the partial, with the missing parts as zeros.
+func (p semPartial) version() semVersion {
+	return semVersion{major: p.major, minor: p.minor, patch: p.patch, prerelease: p.prerelease}
+}
+
+// lowerBound : the primitives for ">" or ">=" the partial.
+func (p semPartial) lowerBound(op string) []semComparator {
+	if p.parts == 0 {
+		if op == ">" {
+			return []semComparator{{"<", semVersion{prerelease: []string{"0"}}, true}}
+		}
+		return nil
+	}
+	if op == ">" && p.parts < 3 {
+		return []semComparator{{">=", p.next(), true}}
+	}
+	return []semComparator{{op, p.version(), false}}
+}
+
+// upperBound : the primitives for "<" or "<=" the partial.
+func (p semPartial) upperBound(op string) []semComparator {
+	if p.parts == 0 {
+		if op == "<" {
+			return []semComparator{{"<", semVersion{prerelease: []string{"0"}}, true}}
+		}
+		return nil
+	}
+	if p.parts == 3 {
+		return []semComparator{{op, p.version(), false}}
+	}
+	if op == "<=" {
+		return []semComparator{{"<", p.next(), true}}
+	}
+	return []semComparator{{"<", semVersion{major: p.major, minor: p.minor, prerelease: []string{"0"}}, true}}
+}
+
+// next : the lowest prerelease of the version following the partial's last present part.
+func (p semPartial) next() semVersion {
+	next := semVersion{major: p.major, minor: p.minor, prerelease: []string{"0"}}
+	if p.parts == 1 {
+		next.major, next.minor = p.major+1, 0
+	} else {
+		next.minor = p.minor + 1
+	}
+	return next
+}
+
+// matches : checks the version against a primitive comparator.
+func (c semComparator) matches(version semVersion) bool {
+	cmp := version.compare(c.version)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return cmp == 0
+	}
+}
+
+// contains : checks if the version satisfies any comparator set of the range.
+// As is customary, a prerelease satisfies a set only if one of its comparators carries a
+// prerelease of the same major, minor and patch, other than the synthetic ones added by desugaring.
+func (r semRange) contains(version semVersion) bool {
+	for _, set := range r {
+		if semSetContains(set, version) {
+			return true
+		}
+	}
+	return false
+}
+
+func semSetContains(set []semComparator, version semVersion) bool {
+	for _, comparator := range set {
+		if !comparator.matches(version) {
+			return false
+		}
+	}
+	if len(version.prerelease) == 0 {
+		return true
+	}
+	for _, comparator := range set {
+		if len(comparator.version.prerelease) > 0 && !comparator.synthetic && comparator.version.sameTriple(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// mustParseSemVer : parses a version provided while building a rule. It panics on an invalid version,
+// the same way as regexp.MustCompile.
+func mustParseSemVer(str string) semVersion {
+	version, ok := parseSemVer(str)
+	if !ok {
+		panic(fmt.Sprintf("valkyrie: invalid semantic version %q", str))
+	}
+	return version
+}
+
+// semVerCompareCheck : creates a check that compares the string, as a version, to the given version.
+func (s *StringRule) semVerCompareCheck(op string, value string) *StringRule {
+	comparator := semComparator{op: op, version: mustParseSemVer(value)}
+	s.addCheck(CodeSemVerCompare, Params{"op": op, "version": value}, func(arg string) error {
+		version, ok := parseSemVer(arg)
+		if !ok || !comparator.matches(version) {
+			return errStringSemVerCompare(op, value)
+		}
+		return nil
+	})
+	return s
+}
+
+// SemVer : Adds a Semantic Versioning 2.0.0 check on the string.
+// Example: "1.0.0", "2.1.3-rc.1+build.5"
+func (s *StringRule) SemVer() *StringRule {
+	s.addCheck(CodeSemVer, nil, func(arg string) error {
+		if _, ok := parseSemVer(arg); !ok {
+			return errStringSemVer()
+		}
+		return nil
+	})
+	return s
+}
+
+// SemVerGTE : Adds a check that the string is a version with a precedence '>=' the given version.
+// It panics if the given version is invalid.
+func (s *StringRule) SemVerGTE(value string) *StringRule {
+	return s.semVerCompareCheck(">=", value)
+}
+
+// SemVerLTE : Adds a check that the string is a version with a precedence '<=' the given version.
+// It panics if the given version is invalid.
+func (s *StringRule) SemVerLTE(value string) *StringRule {
+	return s.semVerCompareCheck("<=", value)
+}
+
+// SemVerGT : Adds a check that the string is a version with a precedence '>' the given version.
+// It panics if the given version is invalid.
+func (s *StringRule) SemVerGT(value string) *StringRule {
+	return s.semVerCompareCheck(">", value)
+}
+
+// SemVerLT : Adds a check that the string is a version with a precedence '<' the given version.
+// It panics if the given version is invalid.
+func (s *StringRule) SemVerLT(value string) *StringRule {
+	return s.semVerCompareCheck("<", value)
+}
+
+// SemVerConstraint : Adds a check that the string is a valid version range expression.
+// Example: ">=1.2 <2.0 || ^3.1", "~1.4.2", "1.x", "1.2.3 - 2.0"
+func (s *StringRule) SemVerConstraint() *StringRule {
+	s.addCheck(CodeSemVerRange, nil, func(arg string) error {
+		if _, ok := parseSemRange(arg); !ok {
+			return errStringSemVerRange()
+		}
+		return nil
+	})
+	return s
+}
+
+// SemVerSatisfies : Adds a check that the string is a version satisfying the given range expression.
+// It panics if the range expression is invalid.
+func (s *StringRule) SemVerSatisfies(constraint string) *StringRule {
+	versions, ok := parseSemRange(constraint)
+	if !ok {
+		panic(fmt.Sprintf("valkyrie: invalid semantic version range %q", constraint))
+	}
+	s.addCheck(CodeSemVerSatisfies, Params{"range": constraint}, func(arg string) error {
+		version, ok := parseSemVer(arg)
+		if !ok || !versions.contains(version) {
+			return errStringSemVerSatisfies(constraint)
+		}
+		return nil
+	})
+	return s
+}
@@ -0,0 +1,138 @@
+package valkyrie
+
+import "testing"
+
+func TestSemVerSatisfiesPrerelease(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{">1.2", "1.3.0", true},
+		{">1.2", "1.3.0-alpha", false},
+		{">1.2", "1.2.9", false},
+		{">1", "2.0.0-0", false},
+		{">1.2.3-alpha.1", "1.2.3-alpha.2", true},
+		{">1.2.3-alpha.1", "1.2.4-alpha", false},
+
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.2.5-alpha", false},
+		{"<=1.2", "1.3.0-0", false},
+		{"<1.2.3", "1.2.3-beta", false},
+		{"<1.2.3-rc.1", "1.2.3-beta", true},
+
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "2.0.0", false},
+		{"^1.2.3", "2.0.0-alpha", false},
+		{"^1.2.3", "1.5.0-beta", false},
+		{"^1.2.3-beta.2", "1.2.3-beta.4", true},
+		{"^1.2.3-beta.2", "1.2.3", true},
+		{"^1.2.3-beta.2", "1.2.4-beta", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.4", false},
+
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2.3", "1.3.0-0", false},
+		{"~1.2", "1.3.0-alpha", false},
+		{"~1.2.3-beta.2", "1.2.3-beta.3", true},
+		{"~1.2.3-beta.2", "1.2.4-beta.3", false},
+
+		{"1.2.x", "1.2.7", true},
+		{"1.2.x", "1.2.7-rc.1", false},
+		{"1.2.x", "1.3.0", false},
+		{"1.x", "2.0.0-0", false},
+		{"*", "3.0.0", true},
+		{"*", "1.0.0-rc", false},
+
+		{"1.x || >=2.5.0", "2.4.0", false},
+		{"1.x || >=2.5.0", "2.6.0", true},
+		{"1.2 - 2.3.4", "1.2.0", true},
+		{"1.2 - 2.3.4", "2.3.4", true},
+		{"1.2 - 2.3.4", "2.3.5", false},
+		{"1.2 - 2.3", "2.3.9", true},
+		{"1.2 - 2.3", "2.4.0-0", false},
+	}
+
+	for _, test := range tests {
+		rule := PureString().SemVerSatisfies(test.constraint)
+		if got := rule.Apply(test.version) == nil; got != test.want {
+			t.Errorf("SemVerSatisfies(%q).Apply(%q) passed = %v, want %v", test.constraint, test.version, got, test.want)
+		}
+	}
+}
+
+func TestSemVer(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"1.0.0", true},
+		{"0.0.0", true},
+		{"2.1.3-rc.1+build.5", true},
+		{"1.0.0-alpha.beta.1", true},
+		{"1.0.0+20130313144700", true},
+		{"1.0", false},
+		{"01.0.0", false},
+		{"1.0.0-01", false},
+		{"1.0.0-", false},
+		{"1.0.0+", false},
+		{"v1.0.0", false},
+		{"1.0.0-alpha..1", false},
+	}
+
+	for _, test := range tests {
+		if got := PureString().SemVer().Apply(test.version) == nil; got != test.want {
+			t.Errorf("SemVer().Apply(%q) passed = %v, want %v", test.version, got, test.want)
+		}
+	}
+}
+
+func TestSemVerCompare(t *testing.T) {
+	tests := []struct {
+		rule    *StringRule
+		version string
+		want    bool
+	}{
+		{PureString().SemVerGTE("1.2.3"), "1.2.3", true},
+		{PureString().SemVerGTE("1.2.3"), "1.2.3-rc.1", false},
+		{PureString().SemVerGT("1.0.0-alpha"), "1.0.0-alpha.1", true},
+		{PureString().SemVerGT("1.0.0-alpha.1"), "1.0.0-alpha.beta", true},
+		{PureString().SemVerGT("1.0.0-beta.2"), "1.0.0-beta.11", true},
+		{PureString().SemVerLT("1.0.0"), "1.0.0-rc.1", true},
+		{PureString().SemVerLTE("1.0.0"), "1.0.0+build", true},
+		{PureString().SemVerLTE("1.0.0"), "invalid", false},
+	}
+
+	for _, test := range tests {
+		if got := test.rule.Apply(test.version) == nil; got != test.want {
+			t.Errorf("Apply(%q) passed = %v, want %v", test.version, got, test.want)
+		}
+	}
+}
+
+func TestSemVerConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       bool
+	}{
+		{">=1.2 <2.0 || ^3.1", true},
+		{"~1.4.2", true},
+		{"1.2.3 - 2.0", true},
+		{">= 1.2", true},
+		{"*", true},
+		{"", false},
+		{">=1.2 ||", false},
+		{"=>1.2", false},
+		{"1.2.x.4", false},
+		{"1.x.3", false},
+		{"1.2-beta", false},
+	}
+
+	for _, test := range tests {
+		if got := PureString().SemVerConstraint().Apply(test.constraint) == nil; got != test.want {
+			t.Errorf("SemVerConstraint().Apply(%q) passed = %v, want %v", test.constraint, got, test.want)
+		}
+	}
+}
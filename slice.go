@@ -0,0 +1,258 @@
+package valkyrie
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// SliceCheck : Represents a function that performs a validation check on a []interface{}.
+type SliceCheck func(arg []interface{}) error
+
+// SliceRule : Rule interface implementation for a slice.
+type SliceRule struct {
+	// scalar : whether a value that is not a slice is treated as a slice of one element.
+	scalar bool
+	// whites : the list of whitelisted values for this rule.
+	whites whitelist
+	// checks : the list of checks to be performed as part of this rule.
+	checks []SliceCheck
+	// null : how the rule treats null values.
+	null nullability
+	// safe : whether panics raised by the checks are recovered.
+	safe bool
+	// err : the error to be thrown if the rule fails.
+	err error
+}
+
+// SliceRule PRIMARY PUBLIC METHODS #################################
+
+// Allow : Whitelists the provided values for a rule.
+// If the argument is one of the whitelisted values, no checks
+// will be performed upon it. Slices are compared by deep equality.
+func (s *SliceRule) Allow(args ...interface{}) *SliceRule {
+	s.whites.add(false, args...)
+	return s
+}
+
+// AllowLoose : Whitelists the provided values like Allow, but numbers match
+// numbers of any kind with the same value, e.g. int(5) matches int64(5) and float64(5).
+func (s *SliceRule) AllowLoose(args ...interface{}) *SliceRule {
+	s.whites.add(true, args...)
+	return s
+}
+
+// AddCheck : Adds a custom check function to the rule.
+func (s *SliceRule) AddCheck(check SliceCheck) *SliceRule {
+	s.checks = append(s.checks, check)
+	return s
+}
+
+// WithError : Adds a custom error to the rule.
+// This custom error (if not nil) will be thrown on every check violation
+// instead of the original error.
+// It is returned as it is, without the code and the key path of the violation; MsgError keeps them.
+func (s *SliceRule) WithError(err error) *SliceRule {
+	s.err = err
+	return s
+}
+
+// Nullable : Makes the rule pass null values, i.e. nil or nil pointers.
+func (s *SliceRule) Nullable() *SliceRule {
+	s.null = nullAllowed
+	return s
+}
+
+// NotNull : Makes the rule fail null values, i.e. nil or nil pointers, with a dedicated error.
+func (s *SliceRule) NotNull() *SliceRule {
+	s.null = nullDenied
+	return s
+}
+
+// Recover : Makes the rule recover panics raised by its checks and nested rules,
+// and report them as a ValidationError with the CodeInternal code and the stack trace.
+func (s *SliceRule) Recover() *SliceRule {
+	s.safe = true
+	return s
+}
+
+// Msg : Replaces the message of the most recently added check with the template.
+// The template may refer to the params of the check, such as {min}, and to the key path as {field}.
+// Example: PureSlice().LenGTE(1).Msg("{field} must have at least {min} items")
+func (s *SliceRule) Msg(template string) *SliceRule {
+	if check := s.lastCheck(); check != nil {
+		s.checks[len(s.checks)-1] = func(arg []interface{}) error { return withMessage(template, check(arg)) }
+	}
+	return s
+}
+
+// MsgError : Replaces the error of the most recently added check with the provided error.
+// The code, the params and the key path of the failure are kept.
+func (s *SliceRule) MsgError(err error) *SliceRule {
+	if check := s.lastCheck(); check != nil {
+		s.checks[len(s.checks)-1] = func(arg []interface{}) error { return withError(err, check(arg)) }
+	}
+	return s
+}
+
+// Apply : Applies the rule on a given argument.
+// Pointers, driver.Valuer implementations and types with a registered Converter
+// are unwrapped before validation.
+func (s *SliceRule) Apply(arg interface{}) (err error) {
+	if s.safe {
+		defer recoverPanic(&err)
+	}
+	arg, isNull := normalize(arg, "")
+	if s.isWhitelisted(arg) {
+		return nil
+	}
+	if isNull && s.null == nullAllowed {
+		return nil
+	}
+	if isNull && s.null == nullDenied {
+		return orErr(s.err, coded(CodeNotNull, nil, errNotNull()))
+	}
+	sliceVal, ok := toSlice(arg)
+	if !ok && s.scalar && !isNull {
+		sliceVal, ok = []interface{}{arg}, true
+	}
+	if !ok {
+		return orErr(s.err, coded(CodeType, Params{"type": "slice"}, errSlice()))
+	}
+
+	if err := s.performChecks(sliceVal); err != nil {
+		return orErr(s.err, err)
+	}
+	return nil
+}
+
+// SliceRule CONSTRUCTORS ###########################################
+
+// PureSlice : Creates a SliceRule which expects the arg to be a slice or an array of any type.
+func PureSlice() *SliceRule {
+	return &SliceRule{}
+}
+
+// ScalarSlice : Creates a SliceRule which expects the arg to be a slice, an array or a single value.
+// which will be validated as a slice of one element.
+// Example: a form key given once, "tag=a", is validated the same way as a repeated one.
+func ScalarSlice() *SliceRule {
+	return &SliceRule{scalar: true}
+}
+
+// SliceRule PRIVATE METHODS ########################################
+
+// addCheck : adds a check whose errors are reported as a ValidationError with the code and the params.
+func (s *SliceRule) addCheck(code string, params Params, check SliceCheck) *SliceRule {
+	return s.AddCheck(func(arg []interface{}) error { return coded(code, params, check(arg)) })
+}
+
+// lastCheck : returns the most recently added check, or nil if there is none.
+func (s *SliceRule) lastCheck() SliceCheck {
+	if len(s.checks) == 0 {
+		return nil
+	}
+	return s.checks[len(s.checks)-1]
+}
+
+func (s *SliceRule) isWhitelisted(value interface{}) bool {
+	return s.whites.contains(value)
+}
+
+func (s *SliceRule) performChecks(arg []interface{}) error {
+	for _, check := range s.checks {
+		if check == nil {
+			continue
+		}
+		if err := check(arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyElement : applies the rule on an element, adding its index to the path of the error.
+func (s *SliceRule) applyElement(rule Rule, index int, element interface{}) (err error) {
+	defer func() { err = withKeyPath(strconv.Itoa(index), err) }()
+	if s.safe {
+		defer recoverPanic(&err)
+	}
+	return rule.Apply(element)
+}
+
+// toSlice : converts slices and arrays of any type into a []interface{}.
+// Byte slices are not treated as slices, since they usually hold text.
+func toSlice(arg interface{}) ([]interface{}, bool) {
+	switch typed := arg.(type) {
+	case []interface{}:
+		return typed, true
+	case []byte:
+		return nil, false
+	}
+
+	value := reflect.ValueOf(arg)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, false
+	}
+	converted := make([]interface{}, value.Len())
+	for i := range converted {
+		converted[i] = value.Index(i).Interface()
+	}
+	return converted, true
+}
+
+// SliceRule UTILITY PUBLIC METHODS  ################################
+
+// LenGTE : Adds a '>=' check on the slice length.
+func (s *SliceRule) LenGTE(value int64) *SliceRule {
+	s.addCheck(CodeItemsGTE, Params{"min": value}, func(arg []interface{}) error {
+		if int64(len(arg)) < value {
+			return errSliceLenGTE(value)
+		}
+		return nil
+	})
+	return s
+}
+
+// LenLTE : Adds a '<=' check on the slice length.
+func (s *SliceRule) LenLTE(value int64) *SliceRule {
+	s.addCheck(CodeItemsLTE, Params{"max": value}, func(arg []interface{}) error {
+		if int64(len(arg)) > value {
+			return errSliceLenLTE(value)
+		}
+		return nil
+	})
+	return s
+}
+
+// Each : Adds a check that every element of the slice passes the rule.
+// The index of a failing element is added to the path of its ValidationError.
+// It panics if the rule is nil.
+func (s *SliceRule) Each(rule Rule) *SliceRule {
+	if isNilRule(rule) {
+		panic("valkyrie: nil rule for slice elements")
+	}
+	s.AddCheck(func(arg []interface{}) error {
+		for i, element := range arg {
+			if err := s.applyElement(rule, i, element); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return s
+}
+
+// Unique : Adds a check that no two elements of the slice are equal, using deep equality.
+func (s *SliceRule) Unique() *SliceRule {
+	s.addCheck(CodeUnique, nil, func(arg []interface{}) error {
+		var seen whitelist
+		for _, element := range arg {
+			if seen.contains(element) {
+				return errSliceUnique()
+			}
+			seen.add(false, element)
+		}
+		return nil
+	})
+	return s
+}
@@ -10,9 +10,15 @@ type StringRule struct {
 	// base : base is the name of the type from which the string value will be inferred.
 	base string
 	// whites : the list of whitelisted values for this rule.
-	whites []interface{}
+	whites whitelist
 	// checks : the list of checks to be performed as part of this rule.
 	checks []StringCheck
+	// decode : the decoder of the most recent encoding check, used by Decoded.
+	decode func(arg string) (interface{}, error)
+	// null : how the rule treats null values.
+	null nullability
+	// safe : whether panics raised by the checks are recovered.
+	safe bool
 	// err : the error to be thrown if the rule fails.
 	err error
 }
@@ -23,7 +29,14 @@ type StringRule struct {
 // If the argument is one of the whitelisted values, no checks
 // will be performed upon it.
 func (s *StringRule) Allow(args ...interface{}) *StringRule {
-	s.whites = append(s.whites, args...)
+	s.whites.add(false, args...)
+	return s
+}
+
+// AllowLoose : Whitelists the provided values like Allow, but numbers match
+// numbers of any kind with the same value, e.g. int(5) matches int64(5) and float64(5).
+func (s *StringRule) AllowLoose(args ...interface{}) *StringRule {
+	s.whites.add(true, args...)
 	return s
 }
 
@@ -36,19 +49,70 @@ func (s *StringRule) AddCheck(check StringCheck) *StringRule {
 // WithError : Adds a custom error to the rule.
 // This custom error (if not nil) will be thrown on every check violation
 // instead of the original error.
+// It is returned as it is, without the code and the key path of the violation; MsgError keeps them.
 func (s *StringRule) WithError(err error) *StringRule {
 	s.err = err
 	return s
 }
 
+// Nullable : Makes the rule pass null values, i.e. nil or nil pointers.
+func (s *StringRule) Nullable() *StringRule {
+	s.null = nullAllowed
+	return s
+}
+
+// NotNull : Makes the rule fail null values, i.e. nil or nil pointers, with a dedicated error.
+func (s *StringRule) NotNull() *StringRule {
+	s.null = nullDenied
+	return s
+}
+
+// Recover : Makes the rule recover panics raised by its checks and nested rules,
+// and report them as a ValidationError with the CodeInternal code and the stack trace.
+func (s *StringRule) Recover() *StringRule {
+	s.safe = true
+	return s
+}
+
+// Msg : Replaces the message of the most recently added check with the template.
+// The template may refer to the params of the check, such as {min}, and to the key path as {field}.
+// Example: PureString().LenGTE(3).Msg("{field} must be at least {min} characters")
+func (s *StringRule) Msg(template string) *StringRule {
+	if check := s.lastCheck(); check != nil {
+		s.checks[len(s.checks)-1] = func(arg string) error { return withMessage(template, check(arg)) }
+	}
+	return s
+}
+
+// MsgError : Replaces the error of the most recently added check with the provided error.
+// The code, the params and the key path of the failure are kept.
+func (s *StringRule) MsgError(err error) *StringRule {
+	if check := s.lastCheck(); check != nil {
+		s.checks[len(s.checks)-1] = func(arg string) error { return withError(err, check(arg)) }
+	}
+	return s
+}
+
 // Apply : Applies the rule on a given argument.
-func (s *StringRule) Apply(arg interface{}) error {
+// Pointers, driver.Valuer implementations and types with a registered Converter
+// are unwrapped before validation.
+func (s *StringRule) Apply(arg interface{}) (err error) {
+	if s.safe {
+		defer recoverPanic(&err)
+	}
+	arg, isNull := normalize(arg, s.base)
 	if s.isWhitelisted(arg) {
 		return nil
 	}
+	if isNull && s.null == nullAllowed {
+		return nil
+	}
+	if isNull && s.null == nullDenied {
+		return orErr(s.err, coded(CodeNotNull, nil, errNotNull()))
+	}
 	str, err := toString(arg, s.base)
 	if err != nil {
-		return orErr(s.err, errString(s.base))
+		return orErr(s.err, coded(CodeType, Params{"type": s.base}, errString(s.base)))
 	}
 
 	if err := s.performChecks(str); err != nil {
@@ -87,13 +151,21 @@ func PureString() *StringRule {
 
 // StringRule PRIVATE METHODS #######################################
 
-func (s *StringRule) isWhitelisted(value interface{}) bool {
-	for _, white := range s.whites {
-		if white == value {
-			return true
-		}
+// addCheck : adds a check whose errors are reported as a ValidationError with the code and the params.
+func (s *StringRule) addCheck(code string, params Params, check StringCheck) *StringRule {
+	return s.AddCheck(func(arg string) error { return coded(code, params, check(arg)) })
+}
+
+// lastCheck : returns the most recently added check, or nil if there is none.
+func (s *StringRule) lastCheck() StringCheck {
+	if len(s.checks) == 0 {
+		return nil
 	}
-	return false
+	return s.checks[len(s.checks)-1]
+}
+
+func (s *StringRule) isWhitelisted(value interface{}) bool {
+	return s.whites.contains(value)
 }
 
 func (s *StringRule) performChecks(arg string) error {
@@ -112,7 +184,7 @@ func (s *StringRule) performChecks(arg string) error {
 
 // LenGTE : Adds a '>=' check on the string length.
 func (s *StringRule) LenGTE(value int64) *StringRule {
-	s.AddCheck(func(arg string) error {
+	s.addCheck(CodeLenGTE, Params{"min": value}, func(arg string) error {
 		if len(arg) < int(value) {
 			return errStringLenGTE(value)
 		}
@@ -123,7 +195,7 @@ func (s *StringRule) LenGTE(value int64) *StringRule {
 
 // LenLTE : Adds a '<=' check on the string length.
 func (s *StringRule) LenLTE(value int64) *StringRule {
-	s.AddCheck(func(arg string) error {
+	s.addCheck(CodeLenLTE, Params{"max": value}, func(arg string) error {
 		if len(arg) > int(value) {
 			return errStringLenLTE(value)
 		}
@@ -134,7 +206,7 @@ func (s *StringRule) LenLTE(value int64) *StringRule {
 
 // LenGT : Adds a '>' check on the string length.
 func (s *StringRule) LenGT(value int64) *StringRule {
-	s.AddCheck(func(arg string) error {
+	s.addCheck(CodeLenGT, Params{"min": value}, func(arg string) error {
 		if len(arg) <= int(value) {
 			return errStringLenGT(value)
 		}
@@ -145,7 +217,7 @@ func (s *StringRule) LenGT(value int64) *StringRule {
 
 // LenLT : Adds a '<' check on the string length.
 func (s *StringRule) LenLT(value int64) *StringRule {
-	s.AddCheck(func(arg string) error {
+	s.addCheck(CodeLenLT, Params{"max": value}, func(arg string) error {
 		if len(arg) >= int(value) {
 			return errStringLenLT(value)
 		}
@@ -156,7 +228,7 @@ func (s *StringRule) LenLT(value int64) *StringRule {
 
 // Pattern : Adds a regex check to the string.
 func (s *StringRule) Pattern(reg *regexp.Regexp) *StringRule {
-	s.AddCheck(func(arg string) error {
+	s.addCheck(CodePattern, Params{"pattern": reg.String()}, func(arg string) error {
 		matches := reg.MatchString(arg)
 		if !matches {
 			return errStringPattern(reg.String())
@@ -168,9 +240,8 @@ func (s *StringRule) Pattern(reg *regexp.Regexp) *StringRule {
 
 // UUIDv4 : Adds a UUIDv4 check on the string.
 func (s *StringRule) UUIDv4() *StringRule {
-	s.AddCheck(func(arg string) error {
-		matches := regexp.MustCompile(uuidRegex).MatchString(arg)
-		if !matches {
+	s.addCheck(CodeUUID, Params{"versions": "4"}, func(arg string) error {
+		if !isValidUUID(arg, UUIDHyphenated, 1<<4) {
 			return errStringUUIDv4()
 		}
 		return nil
@@ -178,9 +249,29 @@ func (s *StringRule) UUIDv4() *StringRule {
 	return s
 }
 
+// UUID : Adds a UUID check on the string, accepting the hyphenated form in any letter case.
+// If versions are provided, the UUID must be one of them, otherwise versions 1 to 8
+// along with the nil (UUIDNil) and max (UUIDMax) UUIDs are accepted.
+func (s *StringRule) UUID(versions ...int) *StringRule {
+	return s.UUIDWithFormat(UUIDHyphenated, versions...)
+}
+
+// UUIDWithFormat : Adds a UUID check on the string, accepting only the given textual format.
+// The versions behave the same way as in UUID.
+func (s *StringRule) UUIDWithFormat(format UUIDFormat, versions ...int) *StringRule {
+	mask, text := uuidVersionMask(versions), uuidVersionsText(versions)
+	s.addCheck(CodeUUID, Params{"versions": text}, func(arg string) error {
+		if !isValidUUID(arg, format, mask) {
+			return errStringUUID(text)
+		}
+		return nil
+	})
+	return s
+}
+
 // Except : Invalidates if arg == provided value
 func (s *StringRule) Except(value string) *StringRule {
-	s.AddCheck(func(arg string) error {
+	s.addCheck(CodeExcept, Params{"value": value}, func(arg string) error {
 		if arg == value {
 			return errStringExcept(value)
 		}
@@ -191,7 +282,7 @@ func (s *StringRule) Except(value string) *StringRule {
 
 // Blind : Invalidates everything except the whitelisted (allowed) values.
 func (s *StringRule) Blind() *StringRule {
-	s.AddCheck(func(arg string) error {
+	s.addCheck(CodeBlind, nil, func(arg string) error {
 		return errBlind
 	})
 	return s
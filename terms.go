@@ -0,0 +1,266 @@
+package valkyrie
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TermMatchMode : Represents where a term may occur in a string to be considered a match.
+type TermMatchMode int
+
+const (
+	// TermSubstring : A term matches anywhere, including inside words. "ass" matches "class".
+	TermSubstring TermMatchMode = iota
+	// TermWholeWord : A term matches only when not surrounded by letters or digits. "ass" does not match "class".
+	TermWholeWord
+)
+
+// TermOptions : Represents the normalizations and the match mode of a TermMatcher.
+// Normalizations apply to the terms as well as to the matched strings.
+type TermOptions struct {
+	// FoldCase : match regardless of letter case.
+	FoldCase bool
+	// Leetspeak : treat common character substitutions as the letters they stand for,
+	// such as "4" and "@" for "a", or "3" for "e".
+	Leetspeak bool
+	// Mode : where a term may occur.
+	Mode TermMatchMode
+}
+
+// TermMatch : Represents an occurrence of a term, with the byte offsets of the occurrence in the matched string.
+type TermMatch struct {
+	Term       string
+	Start, End int
+}
+
+// TermMatcher : Finds occurrences of any of a large set of terms in a single pass over a string,
+// using an Aho-Corasick automaton. It is safe for concurrent use once built.
+type TermMatcher struct {
+	options TermOptions
+	terms   []string
+	// edges : the transitions of the trie, keyed by the source node and the rune.
+	edges map[uint64]int32
+	// fail : the node of the longest proper suffix of each node that is also in the trie.
+	fail []int32
+	// term : the index of the term ending at each node plus one, or 0 if none does.
+	term []int32
+	// dict : the nearest node on the fail chain of each node at which a term ends, or -1.
+	dict []int32
+	// depth : the length in runes of the path to each node.
+	depth []int32
+}
+
+// leetspeak : the character substitutions undone by the Leetspeak option.
+var leetspeak = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '@': 'a', '$': 's', '!': 'i',
+}
+
+// NewTermMatcher : Builds a matcher for the given terms. Empty terms are ignored.
+func NewTermMatcher(terms []string, options TermOptions) *TermMatcher {
+	t := &TermMatcher{
+		options: options,
+		edges:   map[uint64]int32{},
+		fail:    []int32{0},
+		term:    []int32{0},
+		dict:    []int32{-1},
+		depth:   []int32{0},
+	}
+	for _, term := range terms {
+		t.insert(term)
+	}
+	t.link()
+	return t
+}
+
+// ReadTermMatcher : Builds a matcher from a reader with one term per line.
+// Empty lines and lines starting with '#' are skipped.
+func ReadTermMatcher(reader io.Reader, options TermOptions) (*TermMatcher, error) {
+	var terms []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		terms = append(terms, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewTermMatcher(terms, options), nil
+}
+
+// Find : Returns the first occurrence, by end position, of any term in the string.
+func (t *TermMatcher) Find(str string) (TermMatch, bool) {
+	var match TermMatch
+	found := false
+	t.scan(str, func(m TermMatch) bool {
+		match, found = m, true
+		return false
+	})
+	return match, found
+}
+
+// FindAll : Returns all occurrences of the terms in the string, ordered by end position.
+func (t *TermMatcher) FindAll(str string) []TermMatch {
+	var matches []TermMatch
+	t.scan(str, func(m TermMatch) bool {
+		matches = append(matches, m)
+		return true
+	})
+	return matches
+}
+
+// Contains : Checks if any term occurs in the string.
+func (t *TermMatcher) Contains(str string) bool {
+	_, found := t.Find(str)
+	return found
+}
+
+// normalize : applies the case folding and the leetspeak normalizations to a rune.
+func (t *TermMatcher) normalize(r rune) rune {
+	if t.options.Leetspeak {
+		if letter, exists := leetspeak[r]; exists {
+			r = letter
+		}
+	}
+	if t.options.FoldCase {
+		r = unicode.ToLower(r)
+	}
+	return r
+}
+
+// insert : adds the normalized term to the trie.
+func (t *TermMatcher) insert(term string) {
+	if term == "" {
+		return
+	}
+	node := int32(0)
+	for _, r := range term {
+		key := edgeKey(node, t.normalize(r))
+		next, exists := t.edges[key]
+		if !exists {
+			next = int32(len(t.fail))
+			t.edges[key] = next
+			t.fail = append(t.fail, 0)
+			t.term = append(t.term, 0)
+			t.dict = append(t.dict, -1)
+			t.depth = append(t.depth, t.depth[node]+1)
+		}
+		node = next
+	}
+	if t.term[node] == 0 {
+		t.terms = append(t.terms, term)
+		t.term[node] = int32(len(t.terms))
+	}
+}
+
+// link : computes the fail and dictionary links breadth first.
+func (t *TermMatcher) link() {
+	children := make([][]uint64, len(t.fail))
+	for key := range t.edges {
+		children[key>>32] = append(children[key>>32], key)
+	}
+	queue := []int32{0}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, key := range children[node] {
+			child, r := t.edges[key], rune(uint32(key))
+			if node != 0 {
+				t.fail[child] = t.step(t.fail[node], r)
+			}
+			if fail := t.fail[child]; t.term[fail] != 0 {
+				t.dict[child] = fail
+			} else {
+				t.dict[child] = t.dict[fail]
+			}
+			queue = append(queue, child)
+		}
+	}
+}
+
+// step : follows the transition of the rune from the node, falling back along the fail links.
+func (t *TermMatcher) step(node int32, r rune) int32 {
+	for {
+		if next, exists := t.edges[edgeKey(node, r)]; exists {
+			return next
+		}
+		if node == 0 {
+			return 0
+		}
+		node = t.fail[node]
+	}
+}
+
+// scan : reports the occurrences of the terms to the visitor until it returns false.
+func (t *TermMatcher) scan(str string, visit func(TermMatch) bool) {
+	// The normalizations map runes one to one, so rune positions are shared with the original string.
+	offsets := make([]int, 0, len(str)+1)
+	runes := make([]rune, 0, len(str))
+	for offset, r := range str {
+		offsets = append(offsets, offset)
+		runes = append(runes, t.normalize(r))
+	}
+	offsets = append(offsets, len(str))
+
+	node := int32(0)
+	for i, r := range runes {
+		node = t.step(node, r)
+		for match := node; match > 0; match = t.dict[match] {
+			if t.term[match] == 0 {
+				continue
+			}
+			start, end := i+1-int(t.depth[match]), i+1
+			if t.options.Mode == TermWholeWord && !isWholeWord(runes, start, end) {
+				continue
+			}
+			term := t.terms[t.term[match]-1]
+			if !visit(TermMatch{Term: term, Start: offsets[start], End: offsets[end]}) {
+				return
+			}
+		}
+	}
+}
+
+// isWholeWord : checks that the runes in [start, end) are not surrounded by letters or digits.
+func isWholeWord(runes []rune, start, end int) bool {
+	isWordRune := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+	return (start == 0 || !isWordRune(runes[start-1])) && (end == len(runes) || !isWordRune(runes[end]))
+}
+
+func edgeKey(node int32, r rune) uint64 {
+	if r < 0 || r > unicode.MaxRune {
+		r = utf8.RuneError
+	}
+	return uint64(node)<<32 | uint64(uint32(r))
+}
+
+// ContainsAnyOf : Adds a check that the string contains at least one of the matcher's terms.
+func (s *StringRule) ContainsAnyOf(terms *TermMatcher) *StringRule {
+	s.addCheck(CodeContainsAnyOf, nil, func(arg string) error {
+		if !terms.Contains(arg) {
+			return errStringContainsAnyOf()
+		}
+		return nil
+	})
+	return s
+}
+
+// NotContainsAnyOf : Adds a check that the string contains none of the matcher's terms.
+// Example:
+//
+//	reserved, _ := ReadTermMatcher(file, TermOptions{FoldCase: true, Leetspeak: true})
+//	PureString().NotContainsAnyOf(reserved)
+func (s *StringRule) NotContainsAnyOf(terms *TermMatcher) *StringRule {
+	s.addCheck(CodeNotContainsAnyOf, nil, func(arg string) error {
+		if match, found := terms.Find(arg); found {
+			return errStringNotContainsAnyOf(match.Term)
+		}
+		return nil
+	})
+	return s
+}
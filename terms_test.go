@@ -0,0 +1,102 @@
+package valkyrie
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTermMatcherFindAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		terms   []string
+		options TermOptions
+		str     string
+		want    []TermMatch
+	}{
+		{
+			name:  "overlapping terms",
+			terms: []string{"he", "she", "his", "hers"},
+			str:   "ushers",
+			want:  []TermMatch{{"she", 1, 4}, {"he", 2, 4}, {"hers", 2, 6}},
+		},
+		{
+			name:  "fail links",
+			terms: []string{"abcd", "bc"},
+			str:   "abce",
+			want:  []TermMatch{{"bc", 1, 3}},
+		},
+		{
+			name:  "case sensitive",
+			terms: []string{"spam"},
+			str:   "SPAM spam",
+			want:  []TermMatch{{"spam", 5, 9}},
+		},
+		{
+			name:    "fold case",
+			terms:   []string{"Spam"},
+			options: TermOptions{FoldCase: true},
+			str:     "SPAM",
+			want:    []TermMatch{{"Spam", 0, 4}},
+		},
+		{
+			name:    "leetspeak",
+			terms:   []string{"spam"},
+			options: TermOptions{FoldCase: true, Leetspeak: true},
+			str:     "buy $P4M",
+			want:    []TermMatch{{"spam", 4, 8}},
+		},
+		{
+			name:    "whole word",
+			terms:   []string{"ass"},
+			options: TermOptions{Mode: TermWholeWord},
+			str:     "class, ass.",
+			want:    []TermMatch{{"ass", 7, 10}},
+		},
+		{
+			name:  "byte offsets of multi byte runes",
+			terms: []string{"über"},
+			str:   "Grüße über",
+			want:  []TermMatch{{"über", 8, 13}},
+		},
+		{
+			name:  "empty terms are ignored",
+			terms: []string{"", "x"},
+			str:   "ab",
+		},
+	}
+
+	for _, test := range tests {
+		got := NewTermMatcher(test.terms, test.options).FindAll(test.str)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: FindAll(%q) = %v, want %v", test.name, test.str, got, test.want)
+		}
+	}
+}
+
+func TestTermRules(t *testing.T) {
+	matcher, err := ReadTermMatcher(strings.NewReader("admin\n\nroot\n"), TermOptions{FoldCase: true, Mode: TermWholeWord})
+	if err != nil {
+		t.Fatalf("ReadTermMatcher() error = %v", err)
+	}
+	tests := []struct {
+		name string
+		rule *StringRule
+		arg  string
+		want bool
+	}{
+		{"reserved name", PureString().NotContainsAnyOf(matcher), "Admin", false},
+		{"inside a word", PureString().NotContainsAnyOf(matcher), "administrator", true},
+		{"contains a term", PureString().ContainsAnyOf(matcher), "log in as root", true},
+		{"contains no term", PureString().ContainsAnyOf(matcher), "guest", false},
+	}
+
+	for _, test := range tests {
+		if got := test.rule.Apply(test.arg) == nil; got != test.want {
+			t.Errorf("%s: Apply(%q) passed = %v, want %v", test.name, test.arg, got, test.want)
+		}
+	}
+	if err := PureString().NotContainsAnyOf(matcher).Apply("root"); err == nil || err.Error() != errStringNotContainsAnyOf("root").Error() {
+		t.Errorf("NotContainsAnyOf().Apply() = %v, want %v", err, errStringNotContainsAnyOf("root"))
+	}
+}
@@ -1,14 +1,277 @@
 package valkyrie
 
-import "strconv"
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
 
+// nullability : Represents how a rule treats null values, i.e. nil or nil pointers.
+type nullability int
+
+const (
+	// nullDefault : null values are converted like any other value, which fails for all rules.
+	nullDefault nullability = iota
+	// nullAllowed : null values pass without any checks.
+	nullAllowed
+	// nullDenied : null values fail with errNotNull.
+	nullDenied
+)
+
+// orErr : returns err1 if it is not nil, or else err2.
+// Internal errors are never replaced, so the custom errors of rules do not hide them.
 func orErr(err1 error, err2 error) error {
+	if validationErr, ok := err2.(*ValidationError); ok && validationErr.Code == CodeInternal {
+		return err2
+	}
 	if err1 != nil {
 		return err1
 	}
 	return err2
 }
 
+// recoverPanic : recovers a panic and reports it through err as an internal ValidationError.
+// It must be deferred directly.
+func recoverPanic(err *error) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+	*err = &ValidationError{Code: CodeInternal, Stack: debug.Stack(), Err: errInternal(recovered)}
+}
+
+// withKeyPath : prefixes the path of a ValidationError with the key.
+// The elements of a MultiError, such as the failures of a MapRule with AllErrors, are prefixed one by one.
+// Other errors, such as the custom errors of rules, are returned as they are.
+func withKeyPath(key string, err error) error {
+	if multi, ok := err.(MultiError); ok {
+		prefixed := make(MultiError, len(multi))
+		for i, err := range multi {
+			prefixed[i] = withKeyPath(key, err)
+		}
+		return prefixed
+	}
+	if err == nil {
+		return nil
+	}
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		return err
+	}
+	prefixed := *validationErr
+	prefixed.Path = append([]string{key}, validationErr.Path...)
+	return &prefixed
+}
+
+// coded : reports the error of a check as a ValidationError with the code and the params.
+// Errors that already are ValidationErrors are returned as they are.
+func coded(code string, params Params, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*ValidationError); ok {
+		return err
+	}
+	return &ValidationError{Code: code, Params: params, Err: err}
+}
+
+// withMessage : replaces the message of the error with the template.
+// Errors that are not ValidationErrors get the CodeCustom code. Internal errors are left as they are.
+func withMessage(template string, err error) error {
+	if err == nil {
+		return nil
+	}
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		return &ValidationError{Code: CodeCustom, Message: template, Err: err}
+	}
+	if validationErr.Code == CodeInternal {
+		return err
+	}
+	replaced := *validationErr
+	replaced.Message = template
+	return &replaced
+}
+
+// withError : replaces the error of a failed check with the replacement, keeping the code, the params
+// and the path of a ValidationError. Other errors are replaced as they are. Internal errors are left as they are.
+func withError(replacement error, err error) error {
+	if err == nil || replacement == nil {
+		return err
+	}
+	if _, ok := replacement.(*ValidationError); ok {
+		return replacement
+	}
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		return replacement
+	}
+	if validationErr.Code == CodeInternal {
+		return err
+	}
+	replaced := *validationErr
+	replaced.Message = ""
+	replaced.Err = replacement
+	return &replaced
+}
+
+// fillTemplate : replaces the {name} placeholders of the template with the params,
+// and {field} with the given field. Unknown placeholders are kept as they are.
+func fillTemplate(template string, params Params, field string) string {
+	var builder strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		builder.WriteString(template[:start])
+		name := template[start+1 : end]
+		if value, exists := params[name]; exists {
+			builder.WriteString(fmt.Sprint(value))
+		} else if name == "field" {
+			builder.WriteString(field)
+		} else {
+			builder.WriteString(template[start : end+1])
+		}
+		template = template[end+1:]
+	}
+	builder.WriteString(template)
+	return builder.String()
+}
+
+// isNilRule : tells whether the rule is nil, including typed nil pointers such as (*StringRule)(nil).
+func isNilRule(rule Rule) bool {
+	if rule == nil {
+		return true
+	}
+	value := reflect.ValueOf(rule)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
+
+// containsString : tells whether the list contains the value.
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// whitelist : holds the whitelisted values of a rule.
+// Hashable values are looked up in sets, the rest are compared using reflect.DeepEqual, so no value panics.
+type whitelist struct {
+	// strict : the hashable values which match only values of the same type.
+	strict map[interface{}]struct{}
+	// loose : the hashable values which match numbers of any kind, keyed by their normalized form.
+	loose map[interface{}]struct{}
+	// composite : the unhashable values, such as maps and slices.
+	composite []interface{}
+}
+
+// add : adds the values to the whitelist.
+// If loose is true, numbers match numbers of any kind with the same value.
+func (w *whitelist) add(loose bool, values ...interface{}) {
+	for _, value := range values {
+		if !isHashable(reflect.ValueOf(value)) {
+			w.composite = append(w.composite, value)
+			continue
+		}
+		if !loose {
+			if w.strict == nil {
+				w.strict = map[interface{}]struct{}{}
+			}
+			w.strict[value] = struct{}{}
+			continue
+		}
+		if w.loose == nil {
+			w.loose = map[interface{}]struct{}{}
+		}
+		w.loose[normalizeNumber(value)] = struct{}{}
+	}
+}
+
+// contains : tells whether the value is whitelisted.
+func (w *whitelist) contains(value interface{}) bool {
+	if w.strict == nil && w.loose == nil && w.composite == nil {
+		return false
+	}
+	if !isHashable(reflect.ValueOf(value)) {
+		for _, white := range w.composite {
+			if reflect.DeepEqual(white, value) {
+				return true
+			}
+		}
+		return false
+	}
+	if _, exists := w.strict[value]; exists {
+		return true
+	}
+	if w.loose != nil {
+		_, exists := w.loose[normalizeNumber(value)]
+		return exists
+	}
+	return false
+}
+
+// isHashable : tells whether the value can be used as a map key without panicking.
+func isHashable(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Func:
+		return false
+	case reflect.Interface:
+		return value.IsNil() || isHashable(value.Elem())
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if !isHashable(value.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if !isHashable(value.Field(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// normalizeNumber : converts numbers of any kind into int64 if they are integral and in range,
+// or into float64 otherwise. Unsigned integers beyond the int64 range stay uint64.
+// Values that are not numbers are returned as they are.
+func normalizeNumber(value interface{}) interface{} {
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflected.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if unsigned := reflected.Uint(); unsigned > math.MaxInt64 {
+			return unsigned
+		}
+		return int64(reflected.Uint())
+	case reflect.Float32, reflect.Float64:
+		float := reflected.Float()
+		if float == math.Trunc(float) && float >= math.MinInt64 && float < math.MaxInt64 {
+			return int64(float)
+		}
+		if float == math.Trunc(float) && float >= 0 && float < math.MaxUint64 {
+			return uint64(float)
+		}
+		return float
+	}
+	return value
+}
+
 func toBool(arg interface{}, dataType string) (bool, error) {
 	switch dataType {
 	case boolType:
@@ -17,6 +280,22 @@ func toBool(arg interface{}, dataType string) (bool, error) {
 			return false, errEmpty
 		}
 		return boolVal, nil
+	case intType:
+		intVal, ok := arg.(int64)
+		if !ok || (intVal != 0 && intVal != 1) {
+			return false, errEmpty
+		}
+		return intVal == 1, nil
+	case stringType:
+		str, ok := arg.(string)
+		if !ok {
+			return false, errEmpty
+		}
+		boolVal, err := strconv.ParseBool(str)
+		if err != nil {
+			return false, errEmpty
+		}
+		return boolVal, nil
 	default:
 		return false, errEmpty
 	}
@@ -0,0 +1,194 @@
+package valkyrie
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestCustomErrors(t *testing.T) {
+	custom := errors.New("custom")
+	tests := []struct {
+		name string
+		rule Rule
+		arg  interface{}
+		// same : whether the custom error is returned as it is.
+		same bool
+		code string
+		path []string
+	}{
+		{
+			name: "WithError on the rule",
+			rule: PureString().LenGTE(3).WithError(custom),
+			arg:  "a",
+			same: true,
+		},
+		{
+			name: "WithError on a nested key rule",
+			rule: PureMap().Key("name", true, PureString().LenGTE(3).WithError(custom)),
+			arg:  map[string]interface{}{"name": "a"},
+			same: true,
+		},
+		{
+			name: "MsgError keeps the code and the path",
+			rule: PureMap().Key("age", true, PureInt().GTE(18).MsgError(custom)),
+			arg:  map[string]interface{}{"age": int64(3)},
+			code: CodeGTE,
+			path: []string{"age"},
+		},
+		{
+			name: "MsgError on a custom check",
+			rule: PureString().AddCheck(func(string) error { return errors.New("original") }).MsgError(custom),
+			arg:  "a",
+			same: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.rule.Apply(test.arg)
+			if !errors.Is(err, custom) {
+				t.Fatalf("Apply() = %v, want an error wrapping %v", err, custom)
+			}
+			if test.same {
+				if err != custom {
+					t.Errorf("Apply() = %#v, want the custom error itself", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Apply() = %#v, want a *ValidationError", err)
+			}
+			if validationErr.Code != test.code || !reflect.DeepEqual(validationErr.Path, test.path) {
+				t.Errorf("Apply() code = %q, path = %v, want %q, %v",
+					validationErr.Code, validationErr.Path, test.code, test.path)
+			}
+		})
+	}
+}
+
+func TestMsgTemplate(t *testing.T) {
+	username := PureString().LenGTE(3).Msg("{field} needs {min} characters").
+		Pattern(regexp.MustCompile("^[a-z]+$")).Msg("{field} has invalid characters")
+	tests := []struct {
+		name string
+		rule Rule
+		arg  interface{}
+		want string
+	}{
+		{"params and field", PureMap().Key("name", true, username), map[string]interface{}{"name": "a"},
+			"name needs 3 characters"},
+		{"per check", PureMap().Key("name", true, username), map[string]interface{}{"name": "ABC"},
+			"name has invalid characters"},
+		{"root field", PureInt().GTE(18).Msg("{field} must be {min}+"), int64(3), "value must be 18+"},
+		{"unknown placeholder", PureInt().GTE(18).Msg("{nope} {min}"), int64(3), "{nope} 18"},
+		{"unclosed placeholder", PureInt().GTE(18).Msg("{min} {max"), int64(3), "18 {max"},
+		{"only the last check", PureString().LenGTE(3).Pattern(regexp.MustCompile("^a")).Msg("custom"), "b", errStringLenGTE(3).Error()},
+	}
+
+	for _, test := range tests {
+		if err := test.rule.Apply(test.arg); err == nil || err.Error() != test.want {
+			t.Errorf("%s: Apply() = %v, want %q", test.name, err, test.want)
+		}
+	}
+}
+
+func TestWithKeyPathMultiError(t *testing.T) {
+	custom := errors.New("custom")
+	err := withKeyPath("user", MultiError{&ValidationError{Path: []string{"name"}, Err: errEmpty}, custom})
+	multi, ok := err.(MultiError)
+	if !ok || len(multi) != 2 {
+		t.Fatalf("withKeyPath() = %#v, want a MultiError of 2", err)
+	}
+	if field := multi[0].(*ValidationError).Field(); field != "user.name" {
+		t.Errorf("withKeyPath() field = %q, want %q", field, "user.name")
+	}
+	if multi[1] != custom {
+		t.Errorf("withKeyPath() = %#v, want the custom error as it is", multi[1])
+	}
+}
+
+func TestWhitelist(t *testing.T) {
+	many := make([]interface{}, 10000)
+	for i := range many {
+		many[i] = fmt.Sprintf("item-%d", i)
+	}
+	tests := []struct {
+		name string
+		rule Rule
+		arg  interface{}
+		want bool
+	}{
+		{"string", PureString().Allow("a").Blind(), "a", true},
+		{"string not listed", PureString().Allow("a").Blind(), "b", false},
+		{"large list", PureString().Allow(many...).Blind(), "item-9999", true},
+		{"strict int", PureInt().Allow(5).GTE(10), int64(5), false},
+		{"strict int64", PureInt().Allow(int64(5)).GTE(10), int64(5), true},
+		{"loose int", PureInt().AllowLoose(5).GTE(10), int64(5), true},
+		{"loose uint8 and float", PureFloat().AllowLoose(uint8(5)).GTE(10), 5.0, true},
+		{"loose fraction", PureFloat().AllowLoose(5).GTE(10), 5.5, false},
+		{"map", PureMap().Allow(map[string]interface{}{"a": int64(1)}).Key("b", true, PureInt()), map[string]interface{}{"a": int64(1)}, true},
+		{"map not listed", PureMap().Allow(map[string]interface{}{"a": int64(1)}).Key("b", true, PureInt()), map[string]interface{}{"a": int64(2)}, false},
+		{"slice", PureSlice().Allow([]interface{}{"x"}).LenGTE(2), []interface{}{"x"}, true},
+		{"map against a string list", PureString().Allow("a", []int{1}).Blind(), map[string]interface{}{}, false},
+		{"uncomparable struct", PureString().Allow(struct{ list []int }{}).Blind(), "a", false},
+	}
+
+	for _, test := range tests {
+		if got := test.rule.Apply(test.arg) == nil; got != test.want {
+			t.Errorf("%s: Apply(%#v) passed = %v, want %v", test.name, test.arg, got, test.want)
+		}
+	}
+}
+
+func TestRecover(t *testing.T) {
+	panicking := func(string) error { panic("boom") }
+	tests := []struct {
+		name string
+		rule Rule
+		arg  interface{}
+		path []string
+	}{
+		{"string check", PureString().AddCheck(panicking).Recover(), "a", nil},
+		{"int check", PureInt().AddCheck(func(int64) error { panic("boom") }).Recover(), int64(1), nil},
+		{"nested rule", PureMap().Recover().Key("name", true, PureString().AddCheck(panicking)),
+			map[string]interface{}{"name": "a"}, []string{"name"}},
+		{"map check", PureMap().AddCheck(func(map[string]interface{}) error { panic("boom") }).Recover(),
+			map[string]interface{}{}, nil},
+	}
+
+	for _, test := range tests {
+		err := test.rule.Apply(test.arg)
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Code != CodeInternal {
+			t.Errorf("%s: Apply() = %#v, want code %q", test.name, err, CodeInternal)
+			continue
+		}
+		if len(validationErr.Stack) == 0 || !reflect.DeepEqual(validationErr.Path, test.path) {
+			t.Errorf("%s: Apply() stack size = %d, path = %v, want a stack and %v",
+				test.name, len(validationErr.Stack), validationErr.Path, test.path)
+		}
+	}
+}
+
+func TestNoRecover(t *testing.T) {
+	defer func() {
+		if recovered := recover(); recovered != "boom" {
+			t.Errorf("Apply() recovered %v, want the panic to propagate", recovered)
+		}
+	}()
+	_ = PureString().AddCheck(func(string) error { panic("boom") }).Apply("a")
+}
+
+func TestKeyNilRule(t *testing.T) {
+	defer func() {
+		if recovered := recover(); recovered == nil {
+			t.Error("Key() did not panic for a nil rule")
+		}
+	}()
+	var rule *StringRule
+	PureMap().Key("name", true, rule)
+}
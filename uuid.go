@@ -0,0 +1,168 @@
+package valkyrie
+
+import "fmt"
+
+// UUIDFormat : Represents the textual forms of a UUID that are accepted by a UUID check.
+type UUIDFormat int
+
+const (
+	// UUIDHyphenated : Accepts the 36 character hyphenated form in any letter case.
+	// Example: 6BA7B810-9dad-11d1-80b4-00c04fd430c8
+	UUIDHyphenated UUIDFormat = iota
+	// UUIDCanonical : Accepts only the lowercase 36 character hyphenated form.
+	// Example: 6ba7b810-9dad-11d1-80b4-00c04fd430c8
+	UUIDCanonical
+	// UUIDAnyForm : Accepts the hyphenated form along with the braced, URN and
+	// 32 character unhyphenated forms, in any letter case.
+	// Example: {6ba7b810-9dad-11d1-80b4-00c04fd430c8}, urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8
+	UUIDAnyForm
+)
+
+const (
+	// UUIDNil : The pseudo-version that matches the nil UUID (all 128 bits zero).
+	UUIDNil = 0x0
+	// UUIDMax : The pseudo-version that matches the max UUID (all 128 bits one).
+	UUIDMax = 0xf
+)
+
+// uuidAllVersions : mask of v1 to v8 along with the nil and max UUIDs.
+const uuidAllVersions uint16 = 1<<UUIDNil | 0x1fe | 1<<UUIDMax
+
+// uuidHexTable : maps an ASCII byte to its hex value, or 0xff if it is not a hex digit.
+var uuidHexTable = func() (table [256]byte) {
+	for i := range table {
+		table[i] = 0xff
+	}
+	for c := '0'; c <= '9'; c++ {
+		table[c] = byte(c - '0')
+	}
+	for c := 'a'; c <= 'f'; c++ {
+		table[c] = byte(c-'a') + 10
+		table[c-'a'+'A'] = byte(c-'a') + 10
+	}
+	return
+}()
+
+// uuidVersionMask : converts the given versions into a bit mask.
+// An empty list of versions yields the mask of all supported versions.
+func uuidVersionMask(versions []int) uint16 {
+	if len(versions) == 0 {
+		return uuidAllVersions
+	}
+	var mask uint16
+	for _, v := range versions {
+		if v >= 0 && v <= 0xf {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask
+}
+
+// parseUUID : parses the provided string into its 16 bytes as per the given format.
+// It does not allocate.
+func parseUUID(str string, format UUIDFormat) (uuid [16]byte, ok bool) {
+	if format == UUIDAnyForm {
+		switch {
+		case len(str) == 38 && str[0] == '{' && str[37] == '}':
+			str = str[1:37]
+		case len(str) == 45 && isURNUUIDPrefix(str[:9]):
+			str = str[9:]
+		case len(str) == 32:
+			return parseUUIDHex(str, false)
+		}
+	}
+	if len(str) != 36 || str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+		return uuid, false
+	}
+
+	lower := format == UUIDCanonical
+	j := 0
+	for _, i := range [...]int{0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28, 30, 32, 34} {
+		hi, lo := str[i], str[i+1]
+		if lower && (isUpperHex(hi) || isUpperHex(lo)) {
+			return uuid, false
+		}
+		h, l := uuidHexTable[hi], uuidHexTable[lo]
+		if h == 0xff || l == 0xff {
+			return uuid, false
+		}
+		uuid[j] = h<<4 | l
+		j++
+	}
+	return uuid, true
+}
+
+// parseUUIDHex : parses 32 contiguous hex characters into 16 bytes.
+func parseUUIDHex(str string, lower bool) (uuid [16]byte, ok bool) {
+	for j := 0; j < 16; j++ {
+		hi, lo := str[2*j], str[2*j+1]
+		if lower && (isUpperHex(hi) || isUpperHex(lo)) {
+			return uuid, false
+		}
+		h, l := uuidHexTable[hi], uuidHexTable[lo]
+		if h == 0xff || l == 0xff {
+			return uuid, false
+		}
+		uuid[j] = h<<4 | l
+	}
+	return uuid, true
+}
+
+// uuidVersion : returns the version of the given UUID bytes, UUIDNil or UUIDMax for the
+// special UUIDs, or -1 if the variant is not the one defined by RFC 9562.
+func uuidVersion(uuid [16]byte) int {
+	allZero, allOne := true, true
+	for _, b := range uuid {
+		allZero = allZero && b == 0x00
+		allOne = allOne && b == 0xff
+	}
+	switch {
+	case allZero:
+		return UUIDNil
+	case allOne:
+		return UUIDMax
+	case uuid[8]&0xc0 != 0x80:
+		return -1
+	}
+	version := int(uuid[6] >> 4)
+	if version < 1 || version > 8 {
+		return -1
+	}
+	return version
+}
+
+// isValidUUID : checks if the string is a UUID in the given format whose version is in the mask.
+func isValidUUID(str string, format UUIDFormat, mask uint16) bool {
+	uuid, ok := parseUUID(str, format)
+	if !ok {
+		return false
+	}
+	version := uuidVersion(uuid)
+	return version >= 0 && mask&(1<<uint(version)) != 0
+}
+
+// uuidVersionsText : describes the accepted versions for error messages.
+func uuidVersionsText(versions []int) string {
+	if len(versions) == 0 {
+		return "of any version"
+	}
+	return fmt.Sprintf("of versions %v", versions)
+}
+
+func isURNUUIDPrefix(prefix string) bool {
+	const urn = "urn:uuid:"
+	for i := 0; i < len(urn); i++ {
+		c := prefix[i]
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != urn[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isUpperHex(c byte) bool {
+	return c >= 'A' && c <= 'F'
+}
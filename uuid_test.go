@@ -0,0 +1,69 @@
+package valkyrie
+
+import "testing"
+
+func TestUUID(t *testing.T) {
+	tests := []struct {
+		str      string
+		format   UUIDFormat
+		versions []int
+		want     bool
+	}{
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", UUIDHyphenated, nil, true},
+		{"6BA7B810-9DAD-11D1-80B4-00C04FD430C8", UUIDHyphenated, nil, true},
+		{"6BA7B810-9DAD-11D1-80B4-00C04FD430C8", UUIDCanonical, nil, false},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", UUIDCanonical, []int{1}, true},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", UUIDHyphenated, []int{4}, false},
+		{"550e8400-e29b-41d4-a716-446655440000", UUIDHyphenated, []int{4}, true},
+		{"01890a5d-ac96-774b-bcce-b302099a8057", UUIDHyphenated, []int{7}, true},
+		{"00000000-0000-0000-0000-000000000000", UUIDHyphenated, nil, true},
+		{"00000000-0000-0000-0000-000000000000", UUIDHyphenated, []int{4}, false},
+		{"00000000-0000-0000-0000-000000000000", UUIDHyphenated, []int{UUIDNil}, true},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", UUIDHyphenated, []int{UUIDMax}, true},
+		{"550e8400-e29b-01d4-a716-446655440000", UUIDHyphenated, nil, false},
+		{"550e8400-e29b-41d4-c716-446655440000", UUIDHyphenated, nil, false},
+		{"550e8400e29b41d4a716446655440000", UUIDHyphenated, nil, false},
+		{"550e8400e29b41d4a716446655440000", UUIDAnyForm, nil, true},
+		{"{550e8400-e29b-41d4-a716-446655440000}", UUIDAnyForm, nil, true},
+		{"URN:UUID:550e8400-e29b-41d4-a716-446655440000", UUIDAnyForm, nil, true},
+		{"{550e8400-e29b-41d4-a716-446655440000", UUIDAnyForm, nil, false},
+		{"550e8400-e29b-41d4-a716-44665544000g", UUIDHyphenated, nil, false},
+		{"550e8400-e29b41d4-a716-4466554400000", UUIDHyphenated, nil, false},
+		{"", UUIDAnyForm, nil, false},
+	}
+
+	for _, test := range tests {
+		rule := PureString().UUIDWithFormat(test.format, test.versions...)
+		if got := rule.Apply(test.str) == nil; got != test.want {
+			t.Errorf("UUIDWithFormat(%v, %v).Apply(%q) passed = %v, want %v",
+				test.format, test.versions, test.str, got, test.want)
+		}
+	}
+}
+
+func TestUUIDv4(t *testing.T) {
+	if err := PureString().UUIDv4().Apply("550e8400-e29b-41d4-a716-446655440000"); err != nil {
+		t.Errorf("UUIDv4().Apply() = %v, want nil", err)
+	}
+	if err := PureString().UUIDv4().Apply("6ba7b810-9dad-11d1-80b4-00c04fd430c8"); err == nil {
+		t.Error("UUIDv4().Apply() = nil for a v1 UUID")
+	}
+}
+
+func TestParseUUIDAllocs(t *testing.T) {
+	strs := []string{
+		"550e8400-e29b-41d4-a716-446655440000",
+		"{550e8400-e29b-41d4-a716-446655440000}",
+		"urn:uuid:550e8400-e29b-41d4-a716-446655440000",
+		"550e8400e29b41d4a716446655440000",
+		"not a uuid",
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, str := range strs {
+			isValidUUID(str, UUIDAnyForm, uuidAllVersions)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("isValidUUID() allocated %v times per run, want 0", allocs)
+	}
+}